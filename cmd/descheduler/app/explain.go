@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/client"
+	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+)
+
+func NewExplainCommand(out io.Writer) *cobra.Command {
+	s, err := options.NewDeschedulerServer()
+	if err != nil {
+		panic(err)
+	}
+
+	var explainCmd = &cobra.Command{
+		Use:   "explain [namespace/]name",
+		Short: "Explain whether the descheduler would evict a pod",
+		Long: `Reports, for every enabled strategy of the policy, whether the strategy would consider
+evicting the pod and which of the evictable constraints the pod passes or fails.
+Only the filters shared by the strategies are evaluated, the strategy specific conditions
+such as the pod age or restarts are not.`,
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, name := "default", args[0]
+			if i := strings.Index(name, "/"); i >= 0 {
+				namespace, name = name[:i], name[i+1:]
+			}
+
+			ctx := context.Background()
+			rsclient, err := client.CreateClient(s.KubeconfigFile)
+			if err != nil {
+				return err
+			}
+			s.Client = rsclient
+
+			policy, err := descheduler.LoadPolicy(ctx, s)
+			if err != nil {
+				return err
+			}
+			evictionPolicyGroupVersion, err := eutils.SupportEviction(s.Client)
+			if err != nil {
+				return err
+			}
+			pod, err := s.Client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+
+			explanations, err := descheduler.ExplainPod(ctx, s, policy, evictionPolicyGroupVersion, pod)
+			if err != nil {
+				return err
+			}
+			printExplanations(out, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name), pod.Spec.NodeName, explanations)
+			return nil
+		},
+	}
+	flags := explainCmd.Flags()
+	flags.StringVar(&s.KubeconfigFile, "kubeconfig", s.KubeconfigFile, "File with  kube configuration.")
+	flags.StringVar(&s.PolicyConfigFile, "policy-config-file", s.PolicyConfigFile, "File with descheduler policy configuration.")
+	flags.StringVar(&s.PolicyName, "policy-name", s.PolicyName, "Name of the cluster scoped DeschedulerPolicy object to read the descheduler policy configuration from, instead of --policy-config-file.")
+	return explainCmd
+}
+
+func printExplanations(out io.Writer, pod, node string, explanations []descheduler.StrategyExplanation) {
+	fmt.Fprintf(out, "Pod %s on node %s\n", pod, node)
+	for _, explanation := range explanations {
+		fmt.Fprintln(out)
+		if !explanation.Evaluated {
+			fmt.Fprintf(out, "%s: not explained, the strategy selects the pods with criteria of its own\n", explanation.Strategy)
+			continue
+		}
+		if explanation.Considered {
+			fmt.Fprintf(out, "%s: considered\n", explanation.Strategy)
+		} else {
+			fmt.Fprintf(out, "%s: not considered\n", explanation.Strategy)
+		}
+		for _, reason := range explanation.Reasons {
+			fmt.Fprintf(out, "  %s\n", reason)
+		}
+		for _, result := range explanation.Evictable.Constraints {
+			if result.Err != nil {
+				fmt.Fprintf(out, "  [fail] %s: %v\n", result.Constraint, result.Err)
+			} else {
+				fmt.Fprintf(out, "  [pass] %s\n", result.Constraint)
+			}
+		}
+		if explanation.Evictable.EvictAnnotation {
+			fmt.Fprintf(out, "  the evict annotation overrides all the constraints but PodDisruptionBudget\n")
+		}
+	}
+}
@@ -18,20 +18,42 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/pflag"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+	componentbaseconfig "k8s.io/component-base/config"
+	componentbaseoptions "k8s.io/component-base/config/options"
 	"k8s.io/component-base/logs"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
+	"sigs.k8s.io/descheduler/pkg/descheduler/health"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
 	deschedulerscheme "sigs.k8s.io/descheduler/pkg/descheduler/scheme"
 )
 
 const (
 	DefaultDeschedulerPort = 10258
+
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+	defaultResourceName  = "descheduler"
+	defaultResourceNs    = "kube-system"
+
+	defaultMaxCycleReports            = 10
+	defaultLivenessIntervalMultiplier = 3
 )
 
 // DeschedulerServer configuration
@@ -39,6 +61,12 @@ type DeschedulerServer struct {
 	componentconfig.DeschedulerConfiguration
 
 	Client         clientset.Interface
+	DynamicClient  dynamic.Interface
+	MetricsClient  metricsclientset.Interface
+	EventRecorder  record.EventRecorder
+	AuditSink      audit.Sink
+	CycleReports   *report.Store
+	Health         *health.State
 	Logs           *logs.Options
 	SecureServing  *apiserveroptions.SecureServingOptionsWithLoopback
 	DisableMetrics bool
@@ -65,6 +93,23 @@ func NewDeschedulerServer() (*DeschedulerServer, error) {
 func (s *DeschedulerServer) Validate() error {
 	var errs []error
 	errs = append(errs, s.Logs.Validate()...)
+	if len(s.PolicyConfigFile) != 0 && len(s.PolicyName) != 0 {
+		errs = append(errs, fmt.Errorf("only one of --policy-config-file and --policy-name can be set"))
+	}
+	if s.LivenessIntervalMultiplier < 0 {
+		errs = append(errs, fmt.Errorf("--liveness-interval-multiplier must not be negative"))
+	}
+	if s.LeaderElection.LeaderElect {
+		if s.DeschedulingInterval.Seconds() == 0 {
+			errs = append(errs, fmt.Errorf("leader election mode needs --descheduling-interval to be set to a non-zero value"))
+		}
+		if s.LeaderElection.RenewDeadline.Duration >= s.LeaderElection.LeaseDuration.Duration {
+			errs = append(errs, fmt.Errorf("leader election renew deadline (%v) must be less than the lease duration (%v)", s.LeaderElection.RenewDeadline.Duration, s.LeaderElection.LeaseDuration.Duration))
+		}
+		if len(s.LeaderElection.ResourceName) == 0 || len(s.LeaderElection.ResourceNamespace) == 0 {
+			errs = append(errs, fmt.Errorf("leader election resource name and namespace must not be empty"))
+		}
+	}
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -75,6 +120,17 @@ func newDefaultComponentConfig() (*componentconfig.DeschedulerConfiguration, err
 	if err := deschedulerscheme.Scheme.Convert(&versionedCfg, &cfg, nil); err != nil {
 		return nil, err
 	}
+	cfg.LeaderElection = componentbaseconfig.LeaderElectionConfiguration{
+		LeaderElect:       false,
+		LeaseDuration:     metav1.Duration{Duration: defaultLeaseDuration},
+		RenewDeadline:     metav1.Duration{Duration: defaultRenewDeadline},
+		RetryPeriod:       metav1.Duration{Duration: defaultRetryPeriod},
+		ResourceLock:      resourcelock.LeasesResourceLock,
+		ResourceName:      defaultResourceName,
+		ResourceNamespace: defaultResourceNs,
+	}
+	cfg.MaxCycleReports = defaultMaxCycleReports
+	cfg.LivenessIntervalMultiplier = defaultLivenessIntervalMultiplier
 	return &cfg, nil
 }
 
@@ -84,6 +140,7 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&rs.DeschedulingInterval, "descheduling-interval", rs.DeschedulingInterval, "Time interval between two consecutive descheduler executions. Setting this value instructs the descheduler to run in a continuous loop at the interval specified.")
 	fs.StringVar(&rs.KubeconfigFile, "kubeconfig", rs.KubeconfigFile, "File with  kube configuration.")
 	fs.StringVar(&rs.PolicyConfigFile, "policy-config-file", rs.PolicyConfigFile, "File with descheduler policy configuration.")
+	fs.StringVar(&rs.PolicyName, "policy-name", rs.PolicyName, "Name of the cluster scoped DeschedulerPolicy object to read the descheduler policy configuration from, instead of --policy-config-file.")
 	fs.BoolVar(&rs.DryRun, "dry-run", rs.DryRun, "execute descheduler in dry run mode.")
 	// node-selector query causes descheduler to run only on nodes that matches the node labels in the query
 	fs.StringVar(&rs.NodeSelector, "node-selector", rs.NodeSelector, "DEPRECATED: selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
@@ -91,7 +148,12 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&rs.MaxNoOfPodsToEvictPerNode, "max-pods-to-evict-per-node", rs.MaxNoOfPodsToEvictPerNode, "DEPRECATED: limits the maximum number of pods to be evicted per node by descheduler")
 	// evict-local-storage-pods allows eviction of pods that are using local storage. This is false by default.
 	fs.BoolVar(&rs.EvictLocalStoragePods, "evict-local-storage-pods", rs.EvictLocalStoragePods, "DEPRECATED: enables evicting pods using local storage by descheduler")
-	fs.BoolVar(&rs.DisableMetrics, "disable-metrics", rs.DisableMetrics, "Disables metrics. The metrics are by default served through https://localhost:10258/metrics. Secure address, resp. port can be changed through --bind-address, resp. --secure-port flags.")
+	fs.StringVar(&rs.AuditLogPath, "audit-log-path", rs.AuditLogPath, "File the audit records of the evictions are appended to as JSON lines, \"-\" means standard output. No audit records are written when empty.")
+	fs.IntVar(&rs.MaxCycleReports, "max-cycle-reports", rs.MaxCycleReports, "Number of reports of the last descheduling cycles served through https://localhost:10258/debug/descheduler/cycles.")
+	fs.IntVar(&rs.LivenessIntervalMultiplier, "liveness-interval-multiplier", rs.LivenessIntervalMultiplier, "Number of descheduling intervals without a completed descheduling cycle after which https://localhost:10258/healthz fails. 0 disables the check.")
+	fs.BoolVar(&rs.DisableMetrics, "disable-metrics", rs.DisableMetrics, "Disables metrics. The metrics are by default served through https://localhost:10258/metrics. Secure address, resp. port can be changed through --bind-address, resp. --secure-port flags. /healthz and /readyz are served regardless.")
+
+	componentbaseoptions.BindLeaderElectionFlags(&rs.LeaderElection, fs)
 
 	rs.SecureServing.AddFlags(fs)
 }
@@ -21,13 +21,17 @@ import (
 	"context"
 	"flag"
 	"io"
+	"time"
 
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/health"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
 
 	"github.com/spf13/cobra"
 
 	apiserver "k8s.io/apiserver/pkg/server"
+	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/apiserver/pkg/server/mux"
 	restclient "k8s.io/client-go/rest"
 	aflag "k8s.io/component-base/cli/flag"
@@ -64,18 +68,25 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 				return
 			}
 
+			s.CycleReports = report.NewStore(s.MaxCycleReports)
+			s.Health = health.NewState(time.Duration(s.LivenessIntervalMultiplier) * s.DeschedulingInterval)
+
+			// the first SIGTERM or SIGINT cancels ctx, the second one exits immediately
+			ctx := apiserver.SetupSignalContext()
+			pathRecorderMux := mux.NewPathRecorderMux("descheduler")
+			healthz.InstallHandler(pathRecorderMux, s.Health.LivenessCheck())
+			healthz.InstallReadyzHandler(pathRecorderMux, s.Health.ReadinessCheck())
+			pathRecorderMux.Handle("/debug/descheduler/cycles", s.CycleReports)
 			if !s.DisableMetrics {
-				ctx := context.TODO()
-				pathRecorderMux := mux.NewPathRecorderMux("descheduler")
 				pathRecorderMux.Handle("/metrics", legacyregistry.HandlerWithReset())
+			}
 
-				if _, err := SecureServing.Serve(pathRecorderMux, 0, ctx.Done()); err != nil {
-					klog.Fatalf("failed to start secure server: %v", err)
-					return
-				}
+			if _, err := SecureServing.Serve(pathRecorderMux, 0, ctx.Done()); err != nil {
+				klog.Fatalf("failed to start secure server: %v", err)
+				return
 			}
 
-			err := Run(s)
+			err := Run(ctx, s)
 			if err != nil {
 				klog.ErrorS(err, "descheduler server")
 			}
@@ -89,6 +100,6 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 	return cmd
 }
 
-func Run(rs *options.DeschedulerServer) error {
-	return descheduler.Run(rs)
+func Run(ctx context.Context, rs *options.DeschedulerServer) error {
+	return descheduler.Run(ctx, rs)
 }
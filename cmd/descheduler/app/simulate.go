@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/simulator"
+)
+
+func NewSimulateCommand(out io.Writer) *cobra.Command {
+	s, err := options.NewDeschedulerServer()
+	if err != nil {
+		panic(err)
+	}
+	var snapshotFile, output string
+	cycles := 1
+
+	var simulateCmd = &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate the descheduler against a cluster snapshot",
+		Long: `Runs the policy against the nodes, pods, PodDisruptionBudgets and PriorityClasses of a snapshot file
+for a number of descheduling cycles, without reaching any cluster. The evicted pods owned by a controller
+are replaced and placed on the least requested node they fit on. Reports the evictions and the utilization
+of the nodes before and after the simulation.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if snapshotFile == "" || s.PolicyConfigFile == "" {
+				return fmt.Errorf("--snapshot and --policy-config-file are required")
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output %q, must be one of text, json", output)
+			}
+			if cycles < 1 {
+				return fmt.Errorf("--cycles must be at least 1, got %d", cycles)
+			}
+
+			policy, err := descheduler.LoadPolicyConfig(s.PolicyConfigFile)
+			if err != nil {
+				return err
+			}
+			objects, err := simulator.LoadSnapshot(snapshotFile)
+			if err != nil {
+				return err
+			}
+			report, err := simulator.Simulate(context.Background(), s, policy, objects, cycles)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(report)
+			}
+			return report.WriteText(out)
+		},
+	}
+	flags := simulateCmd.Flags()
+	flags.StringVar(&snapshotFile, "snapshot", snapshotFile, "YAML or JSON file with the objects of the cluster, e.g. the output of \"kubectl get nodes,pods,pdb,priorityclasses -A -o yaml\".")
+	flags.StringVar(&s.PolicyConfigFile, "policy-config-file", s.PolicyConfigFile, "File with descheduler policy configuration.")
+	flags.IntVar(&cycles, "cycles", cycles, "Number of descheduling cycles to simulate.")
+	flags.StringVar(&output, "output", "text", "Format of the report, text or json.")
+	return simulateCmd
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+)
+
+func NewValidateCommand(out io.Writer) *cobra.Command {
+	var policyConfigFile string
+	outputVersion := v1alpha1.SchemeGroupVersion.Version
+	var validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a descheduler policy",
+		Long: `Loads and validates the policy file without reaching the cluster and prints
+the policy as it is understood by the descheduler, with the defaults applied.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if policyConfigFile == "" {
+				return fmt.Errorf("--policy-config-file is required")
+			}
+			var gv = v1alpha1.SchemeGroupVersion
+			switch outputVersion {
+			case v1alpha1.SchemeGroupVersion.Version:
+			case v1alpha2.SchemeGroupVersion.Version:
+				gv = v1alpha2.SchemeGroupVersion
+			default:
+				return fmt.Errorf("unsupported output version %q, must be one of %v, %v", outputVersion, v1alpha1.SchemeGroupVersion.Version, v1alpha2.SchemeGroupVersion.Version)
+			}
+
+			policy, err := descheduler.LoadPolicyConfig(policyConfigFile)
+			if err != nil {
+				return err
+			}
+			data, err := descheduler.EncodePolicy(policy, gv)
+			if err != nil {
+				return err
+			}
+			_, err = out.Write(data)
+			return err
+		},
+	}
+	validateCmd.Flags().StringVar(&policyConfigFile, "policy-config-file", policyConfigFile, "File with descheduler policy configuration.")
+	validateCmd.Flags().StringVar(&outputVersion, "output-version", outputVersion, "Version of the policy API the policy is printed in, v1alpha1 or v1alpha2.")
+	return validateCmd
+}
@@ -9,18 +9,26 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/informers"
 	"k8s.io/component-base/logs"
+	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/capacity"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/scheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
 )
 
 const (
 	balancePolicy = "balance"
 	placePolicy = "place"
+
+	// balanceStrategy is the strategy the balance policy runs
+	balanceStrategy = "BalancePodsOnNodeForDefragmentation"
 )
 
 var (
@@ -34,7 +42,7 @@ var (
 
 func main(){
 	flag.IntVar(&iterations, "iterations", 1, "balance pods on node iterations.")
-	flag.StringVar(&policy, "policy", "", "place or balance.")
+	flag.StringVar(&policy, "policy", "", "place, balance or the name of a registered strategy.")
 	flag.StringVar(&podName, "pod", "", "place pending pod name.")
 	flag.StringVar(&namespace, "ns", "", "place pod namespace.")
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "kube config file.")
@@ -59,6 +67,8 @@ func main(){
 
 	sharedInformerFactory := informers.NewSharedInformerFactory(rsclient, 0)
 	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+	podInformer.Informer()
 
 	sharedInformerFactory.Start(stopChannel)
 	sharedInformerFactory.WaitForCacheSync(stopChannel)
@@ -74,18 +84,26 @@ func main(){
 		"",
 		false,
 		100,
+		0,
+		0,
 		nodes,
 		false,
 		false,
 		false,
 	)
 
-	if policy != placePolicy &&  policy != balancePolicy {
-		klog.Errorf("please input valid policy: place or balance")
+	// balance runs the BalancePodsOnNodeForDefragmentation strategy, any other registered strategy is run by its name
+	strategyName := api.StrategyName(policy)
+	if policy == balancePolicy {
+		strategyName = balanceStrategy
+	}
+	registered, ok := registry.Lookup(strategyName)
+	if policy != placePolicy && !ok {
+		klog.Errorf("please input valid policy: place, balance or one of %v", registry.Names())
 		os.Exit(1)
 	}
 
-	nodeInfos := capacity.GetSystemSnapshot(ctx, rsclient, nodes)
+	nodeInfos := capacity.GetSystemSnapshot(ctx, rsclient, nodes, nil)
 
 	if policy == placePolicy {
 		if podName == "" {
@@ -114,15 +132,20 @@ func main(){
 				klog.V(1).InfoS(" |-", "pod", klog.KObj(podInfo.Pod))
 			}
 		}
+		sortPods, err := framework.NewSorter(scheduler.SortByLeastRequests)
+		if err != nil {
+			klog.ErrorS(err, "failed to build the pod sorter")
+			return
+		}
 		podInfo := capacity.NewPodInfo(pod)
-		if err := defragmentation.PlaceWorkload(ctx, podEvictor, podInfo, nodeInfos); err != nil {
+		if err := defragmentation.PlaceWorkload(ctx, podEvictor, podInfo, nodeInfos, sortPods); err != nil {
 			klog.ErrorS(err, "place pod across nodes", pod, klog.KObj(pod))
 			return
 		}
 
 		time.Sleep(30 * time.Second)
 		klog.V(1).Infoln("***********************************************************************************")
-		nodeInfos = capacity.GetSystemSnapshot(ctx, rsclient, nodes)
+		nodeInfos = capacity.GetSystemSnapshot(ctx, rsclient, nodes, nil)
 		totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr = capacity.GetNodeResourceUsage(nodeInfos...)
 		klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
 		for rName, rQuanta := range totalSr {
@@ -139,7 +162,22 @@ func main(){
 				klog.V(1).InfoS(" |-", "pod", klog.KObj(podInfo.Pod))
 			}
 		}
-	}else if policy == balancePolicy {
+	}else {
+		// every iteration runs the strategy once, with its default parameters
+		strategy := api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{}}
+		if registered.DefaultParams != nil {
+			strategy.Params = registered.DefaultParams()
+		}
+		var once int32 = 1
+		strategy.Params.Iterations = &once
+		if registered.ValidateParams != nil {
+			if err := registered.ValidateParams(strategy.Params, field.NewPath("params")).ToAggregate(); err != nil {
+				klog.ErrorS(err, "invalid strategy parameters", "strategy", strategyName)
+				os.Exit(1)
+			}
+		}
+		run := registered.New()
+
 		klog.V(1).Infoln("***********************************************************************************")
 		totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr := capacity.GetNodeResourceUsage(nodeInfos...)
 		klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
@@ -161,15 +199,11 @@ func main(){
 		currentIteration := 0
 		for currentIteration < iterations {
 			klog.V(1).Infof("This is the %d iteration", currentIteration+1)
-			if err := defragmentation.BalanceWorkload(ctx, rsclient, nodes, podEvictor); err != nil {
-				klog.ErrorS(err, "balance the cpu/memory consumption across nodes")
-				currentIteration++
-				continue
-			}
+			run(ctx, rsclient, strategy, nodes, podEvictor, podInformer)
 
 			time.Sleep(30 * time.Second)
 			klog.V(1).Infoln("***********************************************************************************")
-			nodeInfos = capacity.GetSystemSnapshot(ctx, rsclient, nodes)
+			nodeInfos = capacity.GetSystemSnapshot(ctx, rsclient, nodes, nil)
 			totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr = capacity.GetNodeResourceUsage(nodeInfos...)
 			klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
 			for rName, rQuanta := range totalSr {
@@ -16,5 +16,7 @@ require (
 	k8s.io/component-helpers v0.22.0
 	k8s.io/klog/v2 v2.9.0
 	k8s.io/kubectl v0.20.5
+	k8s.io/metrics v0.22.0
 	sigs.k8s.io/mdtoc v1.0.1
+	sigs.k8s.io/yaml v1.2.0
 )
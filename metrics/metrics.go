@@ -38,6 +38,22 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "namespace"})
 
+	PolicyReloads = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "policy_reloads",
+			Help:           "Number of reloads of the policy file, by the result. 'error' result means the reloaded policy is invalid and the previous one is kept",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result"})
+
+	CyclesSkipped = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycles_skipped",
+			Help:           "Number of descheduling cycles skipped, by the reason. 'nodes error' means the ready nodes could not be listed, 'too few nodes' means fewer nodes than the minimum cluster size are ready",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"reason"})
+
 	buildInfo = metrics.NewGauge(
 		&metrics.GaugeOpts{
 			Subsystem:      DeschedulerSubsystem,
@@ -50,6 +66,8 @@ var (
 
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		PolicyReloads,
+		CyclesSkipped,
 		buildInfo,
 	}
 )
@@ -43,6 +43,62 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
 	MaxNoOfPodsToEvictPerNode *int
+
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
+	MaxNoOfPodsToEvictPerNamespace *int
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted per descheduling cycle.
+	MaxNoOfPodsToEvictTotal *int
+
+	// EvictionGracePeriodSeconds sets the termination grace period of the evicted pods.
+	// The grace period of the pod is used when not set.
+	EvictionGracePeriodSeconds *int64
+
+	// PropagationPolicy sets whether and how garbage collection is performed when pods are evicted.
+	PropagationPolicy *metav1.DeletionPropagation
+
+	// EvictionQPS limits the number of evictions per second. Evictions are not rate limited when not set.
+	EvictionQPS *float32
+
+	// EvictionBurst is the maximum burst of evictions allowed on top of EvictionQPS. Defaults to 1.
+	EvictionBurst *int
+
+	// MaxEvictionRetries is the number of times an eviction rejected with 429 Too Many Requests
+	// is retried during the same descheduling cycle. Rejected evictions are not retried when not set.
+	MaxEvictionRetries *int
+
+	// MinClusterSize is the minimum number of ready nodes the descheduling cycles run with, a cycle being
+	// skipped when fewer nodes are ready. Defaults to 2.
+	MinClusterSize *int
+
+	// NodeConcurrency is the number of nodes the strategies evaluating the nodes one at a time
+	// evaluate in parallel. Defaults to 1.
+	NodeConcurrency *int
+}
+
+// DeschedulerPolicyStatus reports the outcome of the last descheduling cycle run with the policy of a
+// DeschedulerPolicy object
+type DeschedulerPolicyStatus struct {
+	// LastRunTime is when the last descheduling cycle finished
+	LastRunTime *metav1.Time
+
+	// TotalEvicted is the number of pods evicted by the last descheduling cycle
+	TotalEvicted int
+
+	// Strategies holds the number of pods evicted by every strategy run in the last descheduling cycle
+	Strategies []StrategyStatus
+
+	// ValidationErrors lists why the policy is invalid. The last valid policy keeps being used meanwhile.
+	ValidationErrors []string
+}
+
+// StrategyStatus reports the outcome of a strategy in the last descheduling cycle
+type StrategyStatus struct {
+	// Name of the strategy
+	Name StrategyName
+
+	// Evicted is the number of pods evicted by the strategy
+	Evicted int
 }
 
 type StrategyName string
@@ -52,7 +108,8 @@ type DeschedulerStrategy struct {
 	// Enabled or disabled
 	Enabled bool
 
-	// Weight
+	// Weight orders the strategies, the heaviest runs first. It also sets the share of
+	// MaxNoOfPodsToEvictPerNode and MaxNoOfPodsToEvictTotal the strategy can use.
 	Weight int
 
 	// Strategy parameters
@@ -67,8 +124,6 @@ type Namespaces struct {
 }
 
 // Besides Namespaces only one of its members may be specified
-// TODO(jchaloup): move Namespaces ThresholdPriority and ThresholdPriorityClassName to individual strategies
-//  once the policy version is bumped to v1alpha2
 type StrategyParameters struct {
 	NodeResourceUtilizationThresholds *NodeResourceUtilizationThresholds
 	NodeAffinityType                  []string
@@ -82,16 +137,21 @@ type StrategyParameters struct {
 	ThresholdPriorityClassName        string
 	LabelSelector                     *metav1.LabelSelector
 	NodeFit                           bool
-	Iterations			  *int32
+	Iterations                        *int32
+	EvictionGracePeriodSeconds        *int64
+	SortBy                            []string
 }
 
 type Percentage float64
 type ResourceThresholds map[v1.ResourceName]Percentage
 
 type NodeResourceUtilizationThresholds struct {
-	Thresholds       ResourceThresholds
-	TargetThresholds ResourceThresholds
-	NumberOfNodes    int
+	Thresholds             ResourceThresholds
+	TargetThresholds       ResourceThresholds
+	NumberOfNodes          int
+	UsageSource            string
+	MetricsWindowSeconds   *uint
+	UseDeviationThresholds bool
 }
 
 type PodsHavingTooManyRestarts struct {
@@ -34,6 +34,13 @@ const GroupVersion = "v1alpha1"
 // SchemeGroupVersion is group version used to register these objects
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: GroupVersion}
 
+// CustomResourceGroupName is the group of the DeschedulerPolicy custom resource.
+// It differs from GroupName since the group of a custom resource must contain a dot.
+const CustomResourceGroupName = "descheduler.sigs.k8s.io"
+
+// DeschedulerPoliciesResource is the cluster scoped DeschedulerPolicy custom resource
+var DeschedulerPoliciesResource = schema.GroupVersionResource{Group: CustomResourceGroupName, Version: GroupVersion, Resource: "deschedulerpolicies"}
+
 // Kind takes an unqualified kind and returns a Group qualified GroupKind
 func Kind(kind string) schema.GroupKind {
 	return SchemeGroupVersion.WithKind(kind).GroupKind()
@@ -23,6 +23,8 @@ import (
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// DeschedulerPolicy configures the strategies run by the descheduler. It is the content of the
+// policy file, and the spec of a DeschedulerPolicyObject.
 type DeschedulerPolicy struct {
 	metav1.TypeMeta `json:",inline"`
 
@@ -43,6 +45,77 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
 	MaxNoOfPodsToEvictPerNode *int `json:"maxNoOfPodsToEvictPerNode,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
+	MaxNoOfPodsToEvictPerNamespace *int `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted per descheduling cycle.
+	MaxNoOfPodsToEvictTotal *int `json:"maxNoOfPodsToEvictTotal,omitempty"`
+
+	// EvictionGracePeriodSeconds sets the termination grace period of the evicted pods.
+	// The grace period of the pod is used when not set.
+	EvictionGracePeriodSeconds *int64 `json:"evictionGracePeriodSeconds,omitempty"`
+
+	// PropagationPolicy sets whether and how garbage collection is performed when pods are evicted.
+	PropagationPolicy *metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+
+	// EvictionQPS limits the number of evictions per second. Evictions are not rate limited when not set.
+	EvictionQPS *float32 `json:"evictionQPS,omitempty"`
+
+	// EvictionBurst is the maximum burst of evictions allowed on top of EvictionQPS. Defaults to 1.
+	EvictionBurst *int `json:"evictionBurst,omitempty"`
+
+	// MaxEvictionRetries is the number of times an eviction rejected with 429 Too Many Requests
+	// is retried during the same descheduling cycle. Rejected evictions are not retried when not set.
+	MaxEvictionRetries *int `json:"maxEvictionRetries,omitempty"`
+
+	// MinClusterSize is the minimum number of ready nodes the descheduling cycles run with, a cycle being
+	// skipped when fewer nodes are ready. Defaults to 2.
+	MinClusterSize *int `json:"minClusterSize,omitempty"`
+
+	// NodeConcurrency is the number of nodes the strategies evaluating the nodes one at a time
+	// evaluate in parallel. Defaults to 1.
+	NodeConcurrency *int `json:"nodeConcurrency,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerPolicyObject is the cluster scoped DeschedulerPolicy custom resource of the
+// descheduler.sigs.k8s.io group, the descheduler running the policy of its spec.
+type DeschedulerPolicyObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the policy, holding the same fields as the policy file
+	Spec DeschedulerPolicy `json:"spec,omitempty"`
+
+	// Status reports the outcome of the descheduling cycles run with the policy
+	Status DeschedulerPolicyStatus `json:"status,omitempty"`
+}
+
+// DeschedulerPolicyStatus reports the outcome of the last descheduling cycle run with the policy of a
+// DeschedulerPolicyObject
+type DeschedulerPolicyStatus struct {
+	// LastRunTime is when the last descheduling cycle finished
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// TotalEvicted is the number of pods evicted by the last descheduling cycle
+	TotalEvicted int `json:"totalEvicted"`
+
+	// Strategies holds the number of pods evicted by every strategy run in the last descheduling cycle
+	Strategies []StrategyStatus `json:"strategies,omitempty"`
+
+	// ValidationErrors lists why the policy is invalid. The last valid policy keeps being used meanwhile.
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+}
+
+// StrategyStatus reports the outcome of a strategy in the last descheduling cycle
+type StrategyStatus struct {
+	// Name of the strategy
+	Name StrategyName `json:"name"`
+
+	// Evicted is the number of pods evicted by the strategy
+	Evicted int `json:"evicted"`
 }
 
 type StrategyName string
@@ -52,7 +125,8 @@ type DeschedulerStrategy struct {
 	// Enabled or disabled
 	Enabled bool `json:"enabled,omitempty"`
 
-	// Weight
+	// Weight orders the strategies, the heaviest runs first. It also sets the share of
+	// MaxNoOfPodsToEvictPerNode and MaxNoOfPodsToEvictTotal the strategy can use.
 	Weight int `json:"weight,omitempty"`
 
 	// Strategy parameters
@@ -80,16 +154,21 @@ type StrategyParameters struct {
 	ThresholdPriorityClassName        string                             `json:"thresholdPriorityClassName"`
 	LabelSelector                     *metav1.LabelSelector              `json:"labelSelector"`
 	NodeFit                           bool                               `json:"nodeFit"`
-	Iterations		          *int32			     `json:"iterations"`
+	Iterations                        *int32                             `json:"iterations"`
+	EvictionGracePeriodSeconds        *int64                             `json:"evictionGracePeriodSeconds,omitempty"`
+	SortBy                            []string                           `json:"sortBy,omitempty"`
 }
 
 type Percentage float64
 type ResourceThresholds map[v1.ResourceName]Percentage
 
 type NodeResourceUtilizationThresholds struct {
-	Thresholds       ResourceThresholds `json:"thresholds,omitempty"`
-	TargetThresholds ResourceThresholds `json:"targetThresholds,omitempty"`
-	NumberOfNodes    int                `json:"numberOfNodes,omitempty"`
+	Thresholds             ResourceThresholds `json:"thresholds,omitempty"`
+	TargetThresholds       ResourceThresholds `json:"targetThresholds,omitempty"`
+	NumberOfNodes          int                `json:"numberOfNodes,omitempty"`
+	UsageSource            string             `json:"usageSource,omitempty"`
+	MetricsWindowSeconds   *uint              `json:"metricsWindowSeconds,omitempty"`
+	UseDeviationThresholds bool               `json:"useDeviationThresholds,omitempty"`
 }
 
 type PodsHavingTooManyRestarts struct {
@@ -47,6 +47,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulerPolicyStatus)(nil), (*api.DeschedulerPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(a.(*DeschedulerPolicyStatus), b.(*api.DeschedulerPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DeschedulerPolicyStatus)(nil), (*DeschedulerPolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(a.(*api.DeschedulerPolicyStatus), b.(*DeschedulerPolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerStrategy)(nil), (*api.DeschedulerStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(a.(*DeschedulerStrategy), b.(*api.DeschedulerStrategy), scope)
 	}); err != nil {
@@ -127,6 +137,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StrategyStatus)(nil), (*api.StrategyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StrategyStatus_To_api_StrategyStatus(a.(*StrategyStatus), b.(*api.StrategyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StrategyStatus)(nil), (*StrategyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StrategyStatus_To_v1alpha1_StrategyStatus(a.(*api.StrategyStatus), b.(*StrategyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -137,6 +157,15 @@ func autoConvert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(in *Desched
 	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxNoOfPodsToEvictPerNamespace = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictTotal = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionGracePeriodSeconds = (*int64)(unsafe.Pointer(in.EvictionGracePeriodSeconds))
+	out.PropagationPolicy = (*v1.DeletionPropagation)(unsafe.Pointer(in.PropagationPolicy))
+	out.EvictionQPS = (*float32)(unsafe.Pointer(in.EvictionQPS))
+	out.EvictionBurst = (*int)(unsafe.Pointer(in.EvictionBurst))
+	out.MaxEvictionRetries = (*int)(unsafe.Pointer(in.MaxEvictionRetries))
+	out.MinClusterSize = (*int)(unsafe.Pointer(in.MinClusterSize))
+	out.NodeConcurrency = (*int)(unsafe.Pointer(in.NodeConcurrency))
 	return nil
 }
 
@@ -152,6 +181,15 @@ func autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.Des
 	out.EvictSystemCriticalPods = (*bool)(unsafe.Pointer(in.EvictSystemCriticalPods))
 	out.IgnorePVCPods = (*bool)(unsafe.Pointer(in.IgnorePVCPods))
 	out.MaxNoOfPodsToEvictPerNode = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
+	out.MaxNoOfPodsToEvictPerNamespace = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.MaxNoOfPodsToEvictTotal = (*int)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.EvictionGracePeriodSeconds = (*int64)(unsafe.Pointer(in.EvictionGracePeriodSeconds))
+	out.PropagationPolicy = (*v1.DeletionPropagation)(unsafe.Pointer(in.PropagationPolicy))
+	out.EvictionQPS = (*float32)(unsafe.Pointer(in.EvictionQPS))
+	out.EvictionBurst = (*int)(unsafe.Pointer(in.EvictionBurst))
+	out.MaxEvictionRetries = (*int)(unsafe.Pointer(in.MaxEvictionRetries))
+	out.MinClusterSize = (*int)(unsafe.Pointer(in.MinClusterSize))
+	out.NodeConcurrency = (*int)(unsafe.Pointer(in.NodeConcurrency))
 	return nil
 }
 
@@ -160,6 +198,32 @@ func Convert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in *api.Desched
 	return autoConvert_api_DeschedulerPolicy_To_v1alpha1_DeschedulerPolicy(in, out, s)
 }
 
+func autoConvert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in *DeschedulerPolicyStatus, out *api.DeschedulerPolicyStatus, s conversion.Scope) error {
+	out.LastRunTime = (*v1.Time)(unsafe.Pointer(in.LastRunTime))
+	out.TotalEvicted = in.TotalEvicted
+	out.Strategies = *(*[]api.StrategyStatus)(unsafe.Pointer(&in.Strategies))
+	out.ValidationErrors = *(*[]string)(unsafe.Pointer(&in.ValidationErrors))
+	return nil
+}
+
+// Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus is an autogenerated conversion function.
+func Convert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in *DeschedulerPolicyStatus, out *api.DeschedulerPolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DeschedulerPolicyStatus_To_api_DeschedulerPolicyStatus(in, out, s)
+}
+
+func autoConvert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in *api.DeschedulerPolicyStatus, out *DeschedulerPolicyStatus, s conversion.Scope) error {
+	out.LastRunTime = (*v1.Time)(unsafe.Pointer(in.LastRunTime))
+	out.TotalEvicted = in.TotalEvicted
+	out.Strategies = *(*[]StrategyStatus)(unsafe.Pointer(&in.Strategies))
+	out.ValidationErrors = *(*[]string)(unsafe.Pointer(&in.ValidationErrors))
+	return nil
+}
+
+// Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus is an autogenerated conversion function.
+func Convert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in *api.DeschedulerPolicyStatus, out *DeschedulerPolicyStatus, s conversion.Scope) error {
+	return autoConvert_api_DeschedulerPolicyStatus_To_v1alpha1_DeschedulerPolicyStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Weight = in.Weight
@@ -236,6 +300,9 @@ func autoConvert_v1alpha1_NodeResourceUtilizationThresholds_To_api_NodeResourceU
 	out.Thresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
 	out.TargetThresholds = *(*api.ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
 	out.NumberOfNodes = in.NumberOfNodes
+	out.UsageSource = in.UsageSource
+	out.MetricsWindowSeconds = (*uint)(unsafe.Pointer(in.MetricsWindowSeconds))
+	out.UseDeviationThresholds = in.UseDeviationThresholds
 	return nil
 }
 
@@ -248,6 +315,9 @@ func autoConvert_api_NodeResourceUtilizationThresholds_To_v1alpha1_NodeResourceU
 	out.Thresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.Thresholds))
 	out.TargetThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.TargetThresholds))
 	out.NumberOfNodes = in.NumberOfNodes
+	out.UsageSource = in.UsageSource
+	out.MetricsWindowSeconds = (*uint)(unsafe.Pointer(in.MetricsWindowSeconds))
+	out.UseDeviationThresholds = in.UseDeviationThresholds
 	return nil
 }
 
@@ -334,6 +404,8 @@ func autoConvert_v1alpha1_StrategyParameters_To_api_StrategyParameters(in *Strat
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
 	out.NodeFit = in.NodeFit
 	out.Iterations = (*int32)(unsafe.Pointer(in.Iterations))
+	out.EvictionGracePeriodSeconds = (*int64)(unsafe.Pointer(in.EvictionGracePeriodSeconds))
+	out.SortBy = *(*[]string)(unsafe.Pointer(&in.SortBy))
 	return nil
 }
 
@@ -356,6 +428,8 @@ func autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.S
 	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
 	out.NodeFit = in.NodeFit
 	out.Iterations = (*int32)(unsafe.Pointer(in.Iterations))
+	out.EvictionGracePeriodSeconds = (*int64)(unsafe.Pointer(in.EvictionGracePeriodSeconds))
+	out.SortBy = *(*[]string)(unsafe.Pointer(&in.SortBy))
 	return nil
 }
 
@@ -363,3 +437,25 @@ func autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.S
 func Convert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in *api.StrategyParameters, out *StrategyParameters, s conversion.Scope) error {
 	return autoConvert_api_StrategyParameters_To_v1alpha1_StrategyParameters(in, out, s)
 }
+
+func autoConvert_v1alpha1_StrategyStatus_To_api_StrategyStatus(in *StrategyStatus, out *api.StrategyStatus, s conversion.Scope) error {
+	out.Name = api.StrategyName(in.Name)
+	out.Evicted = in.Evicted
+	return nil
+}
+
+// Convert_v1alpha1_StrategyStatus_To_api_StrategyStatus is an autogenerated conversion function.
+func Convert_v1alpha1_StrategyStatus_To_api_StrategyStatus(in *StrategyStatus, out *api.StrategyStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StrategyStatus_To_api_StrategyStatus(in, out, s)
+}
+
+func autoConvert_api_StrategyStatus_To_v1alpha1_StrategyStatus(in *api.StrategyStatus, out *StrategyStatus, s conversion.Scope) error {
+	out.Name = StrategyName(in.Name)
+	out.Evicted = in.Evicted
+	return nil
+}
+
+// Convert_api_StrategyStatus_To_v1alpha1_StrategyStatus is an autogenerated conversion function.
+func Convert_api_StrategyStatus_To_v1alpha1_StrategyStatus(in *api.StrategyStatus, out *StrategyStatus, s conversion.Scope) error {
+	return autoConvert_api_StrategyStatus_To_v1alpha1_StrategyStatus(in, out, s)
+}
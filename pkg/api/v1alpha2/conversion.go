@@ -0,0 +1,457 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// StrategyArgs are the args of a strategy, converted to and from the parameters of the strategy.
+// The args types of the in-tree strategies implement it, so can the ones of out-of-tree strategies.
+type StrategyArgs interface {
+	// ToParams sets the parameters of the strategy out of the args
+	ToParams(out *api.StrategyParameters)
+	// FromParams sets the args out of the parameters of the strategy, dropping the parameters
+	// not applicable to the strategy
+	FromParams(in *api.StrategyParameters)
+}
+
+// StrategyArgsFactory creates empty args of a strategy
+type StrategyArgsFactory func() StrategyArgs
+
+// LookupStrategyArgs gives the factory of the args of the strategy registered under name, false when no
+// strategy is registered under name, and a nil factory when the strategy takes no args. It is set by the
+// strategy registry, which imports the args types of the in-tree strategies and can't be imported here.
+var LookupStrategyArgs func(name StrategyName) (StrategyArgsFactory, bool)
+
+// Convert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy decodes the args of every strategy
+// into the args type the strategy is registered with and converts them into the strategy parameters.
+// The args are decoded strictly, a field unknown to the args type is reported rather than dropped.
+func Convert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
+	if err := autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in, out, s); err != nil {
+		return err
+	}
+
+	// the strategies are decoded in alphabetical order for the errors to be reported in a stable order
+	names := make([]string, 0, len(in.Strategies))
+	for name := range in.Strategies {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var allErrs field.ErrorList
+	for _, name := range names {
+		strategy := in.Strategies[StrategyName(name)]
+		if len(strategy.Args.Raw) == 0 {
+			continue
+		}
+		argsPath := field.NewPath("strategies").Key(name).Child("args")
+		newArgs, err := lookupStrategyArgs(StrategyName(name))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(argsPath, string(strategy.Args.Raw), err.Error()))
+			continue
+		}
+		if newArgs == nil {
+			allErrs = append(allErrs, field.Forbidden(argsPath, "the strategy takes no args"))
+			continue
+		}
+		args := newArgs()
+		if err := decodeStrategyArgs(strategy.Args.Raw, args, argsPath); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		internalStrategy := out.Strategies[api.StrategyName(name)]
+		if internalStrategy.Params == nil {
+			internalStrategy.Params = &api.StrategyParameters{}
+		}
+		args.ToParams(internalStrategy.Params)
+		out.Strategies[api.StrategyName(name)] = internalStrategy
+	}
+	return allErrs.ToAggregate()
+}
+
+// Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy keeps, out of the parameters of every
+// strategy, the ones of the args type the strategy is registered with.
+func Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.DeschedulerPolicy, out *DeschedulerPolicy, s conversion.Scope) error {
+	if err := autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in, out, s); err != nil {
+		return err
+	}
+	for name, strategy := range in.Strategies {
+		if strategy.Params == nil {
+			continue
+		}
+		newArgs, err := lookupStrategyArgs(StrategyName(name))
+		if err != nil {
+			return err
+		}
+		if newArgs == nil {
+			// the parameters shared by the strategies are converted along with the strategy
+			continue
+		}
+		args := newArgs()
+		args.FromParams(strategy.Params)
+		raw, err := json.Marshal(args)
+		if err != nil {
+			return fmt.Errorf("failed encoding args of %v strategy: %v", name, err)
+		}
+		versionedStrategy := out.Strategies[StrategyName(name)]
+		versionedStrategy.Args = runtime.RawExtension{Raw: raw}
+		out.Strategies[StrategyName(name)] = versionedStrategy
+	}
+	return nil
+}
+
+// Convert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy converts the settings shared by all
+// the strategies. The args depend on the strategy name and are converted along with the policy.
+func Convert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(in *DeschedulerStrategy, out *api.DeschedulerStrategy, s conversion.Scope) error {
+	if err := autoConvert_v1alpha2_DeschedulerStrategy_To_api_DeschedulerStrategy(in, out, s); err != nil {
+		return err
+	}
+	if in.EvictionGracePeriodSeconds != nil || len(in.SortBy) > 0 {
+		out.Params = &api.StrategyParameters{EvictionGracePeriodSeconds: in.EvictionGracePeriodSeconds, SortBy: in.SortBy}
+	}
+	return nil
+}
+
+// Convert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy converts the settings shared by all
+// the strategies. The args depend on the strategy name and are converted along with the policy.
+func Convert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(in *api.DeschedulerStrategy, out *DeschedulerStrategy, s conversion.Scope) error {
+	if err := autoConvert_api_DeschedulerStrategy_To_v1alpha2_DeschedulerStrategy(in, out, s); err != nil {
+		return err
+	}
+	if in.Params != nil {
+		out.EvictionGracePeriodSeconds = in.Params.EvictionGracePeriodSeconds
+		out.SortBy = in.Params.SortBy
+	}
+	return nil
+}
+
+// lookupStrategyArgs gives the factory of the args of the strategy with the given name, nil when the
+// strategy takes no args
+func lookupStrategyArgs(name StrategyName) (StrategyArgsFactory, error) {
+	if LookupStrategyArgs == nil {
+		return nil, fmt.Errorf("no strategy registry to look strategy %q up", name)
+	}
+	newArgs, ok := LookupStrategyArgs(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+	return newArgs, nil
+}
+
+// decodeStrategyArgs decodes the raw args into the args type of the strategy, the fields
+// unknown to the args type and the values of the wrong type are reported under the path of the args.
+func decodeStrategyArgs(raw []byte, args StrategyArgs, fldPath *field.Path) *field.Error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(args)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return field.Invalid(fldPath.Child(typeErr.Field), typeErr.Value, fmt.Sprintf("must be of type %v", typeErr.Type))
+	}
+	// encoding/json has no error type for the unknown fields, the name of the field is only in the message
+	if unknown := strings.TrimPrefix(err.Error(), "json: unknown field "); unknown != err.Error() {
+		if name, unquoteErr := strconv.Unquote(unknown); unquoteErr == nil {
+			return field.Forbidden(fldPath.Child(name), "unknown field")
+		}
+	}
+	return field.Invalid(fldPath, string(raw), err.Error())
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemoveDuplicatesArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces = convertNamespacesToInternal(args.Namespaces)
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	if args.ExcludeOwnerKinds != nil {
+		out.RemoveDuplicates = &api.RemoveDuplicates{ExcludeOwnerKinds: args.ExcludeOwnerKinds}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemoveDuplicatesArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces = convertNamespacesFromInternal(in.Namespaces)
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	if in.RemoveDuplicates != nil {
+		args.ExcludeOwnerKinds = in.RemoveDuplicates.ExcludeOwnerKinds
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *LowNodeUtilizationArgs) ToParams(out *api.StrategyParameters) {
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	if args.Thresholds != nil || args.TargetThresholds != nil || args.NumberOfNodes != 0 || args.UsageSource != "" || args.MetricsWindowSeconds != nil || args.UseDeviationThresholds {
+		out.NodeResourceUtilizationThresholds = &api.NodeResourceUtilizationThresholds{
+			Thresholds:             convertResourceThresholdsToInternal(args.Thresholds),
+			TargetThresholds:       convertResourceThresholdsToInternal(args.TargetThresholds),
+			NumberOfNodes:          args.NumberOfNodes,
+			UsageSource:            args.UsageSource,
+			MetricsWindowSeconds:   args.MetricsWindowSeconds,
+			UseDeviationThresholds: args.UseDeviationThresholds,
+		}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *LowNodeUtilizationArgs) FromParams(in *api.StrategyParameters) {
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	if in.NodeResourceUtilizationThresholds != nil {
+		args.Thresholds = convertResourceThresholdsFromInternal(in.NodeResourceUtilizationThresholds.Thresholds)
+		args.TargetThresholds = convertResourceThresholdsFromInternal(in.NodeResourceUtilizationThresholds.TargetThresholds)
+		args.NumberOfNodes = in.NodeResourceUtilizationThresholds.NumberOfNodes
+		args.UsageSource = in.NodeResourceUtilizationThresholds.UsageSource
+		args.MetricsWindowSeconds = in.NodeResourceUtilizationThresholds.MetricsWindowSeconds
+		args.UseDeviationThresholds = in.NodeResourceUtilizationThresholds.UseDeviationThresholds
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *HighNodeUtilizationArgs) ToParams(out *api.StrategyParameters) {
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	if args.Thresholds != nil || args.NumberOfNodes != 0 || args.UsageSource != "" || args.MetricsWindowSeconds != nil {
+		out.NodeResourceUtilizationThresholds = &api.NodeResourceUtilizationThresholds{
+			Thresholds:           convertResourceThresholdsToInternal(args.Thresholds),
+			NumberOfNodes:        args.NumberOfNodes,
+			UsageSource:          args.UsageSource,
+			MetricsWindowSeconds: args.MetricsWindowSeconds,
+		}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *HighNodeUtilizationArgs) FromParams(in *api.StrategyParameters) {
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	if in.NodeResourceUtilizationThresholds != nil {
+		args.Thresholds = convertResourceThresholdsFromInternal(in.NodeResourceUtilizationThresholds.Thresholds)
+		args.NumberOfNodes = in.NodeResourceUtilizationThresholds.NumberOfNodes
+		args.UsageSource = in.NodeResourceUtilizationThresholds.UsageSource
+		args.MetricsWindowSeconds = in.NodeResourceUtilizationThresholds.MetricsWindowSeconds
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemovePodsViolatingInterPodAntiAffinityArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemovePodsViolatingInterPodAntiAffinityArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemovePodsViolatingNodeAffinityArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	out.NodeAffinityType = args.NodeAffinityType
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemovePodsViolatingNodeAffinityArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	args.NodeAffinityType = in.NodeAffinityType
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemovePodsViolatingNodeTaintsArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemovePodsViolatingNodeTaintsArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemovePodsViolatingTopologySpreadConstraintArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	out.IncludeSoftConstraints = args.IncludeSoftConstraints
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemovePodsViolatingTopologySpreadConstraintArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	args.IncludeSoftConstraints = in.IncludeSoftConstraints
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemovePodsHavingTooManyRestartsArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	if args.PodRestartThreshold != 0 || args.IncludingInitContainers {
+		out.PodsHavingTooManyRestarts = &api.PodsHavingTooManyRestarts{
+			PodRestartThreshold:     args.PodRestartThreshold,
+			IncludingInitContainers: args.IncludingInitContainers,
+		}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemovePodsHavingTooManyRestartsArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	if in.PodsHavingTooManyRestarts != nil {
+		args.PodRestartThreshold = in.PodsHavingTooManyRestarts.PodRestartThreshold
+		args.IncludingInitContainers = in.PodsHavingTooManyRestarts.IncludingInitContainers
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *PodLifeTimeArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	if args.MaxPodLifeTimeSeconds != nil || args.PodStatusPhases != nil {
+		out.PodLifeTime = &api.PodLifeTime{
+			MaxPodLifeTimeSeconds: args.MaxPodLifeTimeSeconds,
+			PodStatusPhases:       args.PodStatusPhases,
+		}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *PodLifeTimeArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	if in.PodLifeTime != nil {
+		args.MaxPodLifeTimeSeconds = in.PodLifeTime.MaxPodLifeTimeSeconds
+		args.PodStatusPhases = in.PodLifeTime.PodStatusPhases
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *RemoveFailedPodsArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.ThresholdPriority, out.ThresholdPriorityClassName = args.ThresholdPriority, args.ThresholdPriorityClassName
+	out.NodeFit = args.NodeFit
+	if args.ExcludeOwnerKinds != nil || args.MinPodLifetimeSeconds != nil || args.Reasons != nil || args.IncludingInitContainers {
+		out.FailedPods = &api.FailedPods{
+			ExcludeOwnerKinds:       args.ExcludeOwnerKinds,
+			MinPodLifetimeSeconds:   args.MinPodLifetimeSeconds,
+			Reasons:                 args.Reasons,
+			IncludingInitContainers: args.IncludingInitContainers,
+		}
+	}
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *RemoveFailedPodsArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.ThresholdPriority, args.ThresholdPriorityClassName = in.ThresholdPriority, in.ThresholdPriorityClassName
+	args.NodeFit = in.NodeFit
+	if in.FailedPods != nil {
+		args.ExcludeOwnerKinds = in.FailedPods.ExcludeOwnerKinds
+		args.MinPodLifetimeSeconds = in.FailedPods.MinPodLifetimeSeconds
+		args.Reasons = in.FailedPods.Reasons
+		args.IncludingInitContainers = in.FailedPods.IncludingInitContainers
+	}
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *BalancePodsOnNodeForDefragmentationArgs) ToParams(out *api.StrategyParameters) {
+	out.Iterations = args.Iterations
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *BalancePodsOnNodeForDefragmentationArgs) FromParams(in *api.StrategyParameters) {
+	args.Iterations = in.Iterations
+}
+
+// ToParams sets the parameters of the strategy out of the args
+func (args *PlacePodsOnNodeForDefragmentationArgs) ToParams(out *api.StrategyParameters) {
+	out.Namespaces, out.LabelSelector = convertNamespacesToInternal(args.Namespaces), args.LabelSelector
+	out.Iterations = args.Iterations
+}
+
+// FromParams sets the args out of the parameters of the strategy
+func (args *PlacePodsOnNodeForDefragmentationArgs) FromParams(in *api.StrategyParameters) {
+	args.Namespaces, args.LabelSelector = convertNamespacesFromInternal(in.Namespaces), in.LabelSelector
+	args.Iterations = in.Iterations
+}
+
+func convertNamespacesToInternal(in *Namespaces) *api.Namespaces {
+	if in == nil {
+		return nil
+	}
+	return &api.Namespaces{Include: in.Include, Exclude: in.Exclude}
+}
+
+func convertNamespacesFromInternal(in *api.Namespaces) *Namespaces {
+	if in == nil {
+		return nil
+	}
+	return &Namespaces{Include: in.Include, Exclude: in.Exclude}
+}
+
+func convertResourceThresholdsToInternal(in ResourceThresholds) api.ResourceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := make(api.ResourceThresholds, len(in))
+	for name, percentage := range in {
+		out[name] = api.Percentage(percentage)
+	}
+	return out
+}
+
+func convertResourceThresholdsFromInternal(in api.ResourceThresholds) ResourceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := make(ResourceThresholds, len(in))
+	for name, percentage := range in {
+		out[name] = Percentage(percentage)
+	}
+	return out
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
+)
+
+// customArgs are the args of a strategy registered out of the tree
+type customArgs struct {
+	MaxPodLifeTimeSeconds *uint `json:"maxPodLifeTimeSeconds,omitempty"`
+}
+
+func (args *customArgs) ToParams(out *api.StrategyParameters) {
+	if args.MaxPodLifeTimeSeconds != nil {
+		out.PodLifeTime = &api.PodLifeTime{MaxPodLifeTimeSeconds: args.MaxPodLifeTimeSeconds}
+	}
+}
+
+func (args *customArgs) FromParams(in *api.StrategyParameters) {
+	if in.PodLifeTime != nil {
+		args.MaxPodLifeTimeSeconds = in.PodLifeTime.MaxPodLifeTimeSeconds
+	}
+}
+
+func init() {
+	noop := func() registry.StrategyFunction {
+		return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+		}
+	}
+	registry.Register(registry.Strategy{Name: "CustomWithArgs", New: noop, NewArgs: func() v1alpha2.StrategyArgs { return &customArgs{} }})
+	registry.Register(registry.Strategy{Name: "CustomWithoutArgs", New: noop})
+}
+
+func decodePolicy(t *testing.T, data []byte) *api.DeschedulerPolicy {
+	obj, err := runtime.Decode(scheme.Codecs.UniversalDecoder(), data)
+	if err != nil {
+		t.Fatalf("Unable to decode policy: %v", err)
+	}
+	return obj.(*api.DeschedulerPolicy)
+}
+
+func TestV1alpha1ExamplesRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../../../examples/*.y*ml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Unable to list the examples: %v", err)
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Unable to read %v: %v", file, err)
+			}
+			policy := decodePolicy(t, data)
+
+			encoded, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1alpha2.SchemeGroupVersion), policy)
+			if err != nil {
+				t.Fatalf("Unable to encode policy as v1alpha2: %v", err)
+			}
+			if got := decodePolicy(t, encoded); !reflect.DeepEqual(got, policy) {
+				t.Errorf("Expected policy %+v, got %+v from %s", policy, got, encoded)
+			}
+		})
+	}
+}
+
+func TestRegisteredStrategiesRoundTrip(t *testing.T) {
+	maxPodLifeTimeSeconds := uint(86400)
+	gracePeriodSeconds := int64(30)
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"CustomWithArgs": {
+				Enabled: true,
+				Params:  &api.StrategyParameters{PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds}},
+			},
+			"CustomWithoutArgs": {
+				Enabled: true,
+				Params:  &api.StrategyParameters{EvictionGracePeriodSeconds: &gracePeriodSeconds},
+			},
+		},
+	}
+
+	encoded, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1alpha2.SchemeGroupVersion), policy)
+	if err != nil {
+		t.Fatalf("Unable to encode policy as v1alpha2: %v", err)
+	}
+	if got := decodePolicy(t, encoded); !reflect.DeepEqual(got.Strategies, policy.Strategies) {
+		t.Errorf("Expected strategies %+v, got %+v from %s", policy.Strategies, got.Strategies, encoded)
+	}
+}
+
+func TestV1alpha2StrategyArgs(t *testing.T) {
+	maxPodLifeTimeSeconds := uint(86400)
+	gracePeriodSeconds := int64(30)
+	iterations := int32(10)
+	metricsWindowSeconds := uint(300)
+
+	tests := []struct {
+		description string
+		policy      string
+		expected    api.StrategyList
+		expectedErr string
+	}{
+		{
+			description: "args of the strategy and grace period",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+    enabled: true
+    evictionGracePeriodSeconds: 30
+    args:
+      maxPodLifeTimeSeconds: 86400
+      namespaces:
+        exclude: ["kube-system"]
+  "BalancePodsOnNodeForDefragmentation":
+    enabled: true
+    args:
+      iterations: 10
+  "RemoveDuplicates":
+    enabled: true
+`,
+			expected: api.StrategyList{
+				"PodLifeTime": {
+					Enabled: true,
+					Params: &api.StrategyParameters{
+						PodLifeTime:                &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds},
+						Namespaces:                 &api.Namespaces{Exclude: []string{"kube-system"}},
+						EvictionGracePeriodSeconds: &gracePeriodSeconds,
+					},
+				},
+				"BalancePodsOnNodeForDefragmentation": {
+					Enabled: true,
+					Params:  &api.StrategyParameters{Iterations: &iterations},
+				},
+				"RemoveDuplicates": {Enabled: true},
+			},
+		},
+		{
+			description: "sorters of the strategy",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemovePodsViolatingNodeTaints":
+    enabled: true
+    sortBy: ["LeastRestarts", "Oldest"]
+`,
+			expected: api.StrategyList{
+				"RemovePodsViolatingNodeTaints": {
+					Enabled: true,
+					Params:  &api.StrategyParameters{SortBy: []string{"LeastRestarts", "Oldest"}},
+				},
+			},
+		},
+		{
+			description: "usage source of the strategy",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "HighNodeUtilization":
+    enabled: true
+    args:
+      usageSource: "metrics"
+      metricsWindowSeconds: 300
+`,
+			expected: api.StrategyList{
+				"HighNodeUtilization": {
+					Enabled: true,
+					Params: &api.StrategyParameters{
+						NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+							UsageSource:          "metrics",
+							MetricsWindowSeconds: &metricsWindowSeconds,
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "deviation thresholds of the strategy",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "LowNodeUtilization":
+    enabled: true
+    args:
+      useDeviationThresholds: true
+      thresholds:
+        "cpu": 10
+      targetThresholds:
+        "cpu": 10
+`,
+			expected: api.StrategyList{
+				"LowNodeUtilization": {
+					Enabled: true,
+					Params: &api.StrategyParameters{
+						NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+							Thresholds:             api.ResourceThresholds{"cpu": 10},
+							TargetThresholds:       api.ResourceThresholds{"cpu": 10},
+							UseDeviationThresholds: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "args of an unknown strategy",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "Unknown":
+    enabled: true
+    args:
+      iterations: 10
+`,
+			expectedErr: `strategies[Unknown].args: Invalid value: "{\"iterations\":10}": unknown strategy "Unknown"`,
+		},
+		{
+			description: "args of a registered strategy",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "CustomWithArgs":
+    enabled: true
+    evictionGracePeriodSeconds: 30
+    args:
+      maxPodLifeTimeSeconds: 86400
+  "CustomWithoutArgs":
+    enabled: true
+`,
+			expected: api.StrategyList{
+				"CustomWithArgs": {
+					Enabled: true,
+					Params: &api.StrategyParameters{
+						PodLifeTime:                &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds},
+						EvictionGracePeriodSeconds: &gracePeriodSeconds,
+					},
+				},
+				"CustomWithoutArgs": {Enabled: true},
+			},
+		},
+		{
+			description: "args of a registered strategy taking none",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "CustomWithoutArgs":
+    enabled: true
+    args:
+      iterations: 10
+`,
+			expectedErr: "strategies[CustomWithoutArgs].args: Forbidden: the strategy takes no args",
+		},
+		{
+			description: "unknown field in the args",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "PodLifeTime":
+    enabled: true
+    args:
+      maxPodLifeTimeSecond: 86400
+  "RemoveDuplicates":
+    enabled: true
+    args:
+      excludeOwnerKinds: ["ReplicaSet"]
+`,
+			expectedErr: "strategies[PodLifeTime].args.maxPodLifeTimeSecond: Forbidden: unknown field",
+		},
+		{
+			description: "args of the wrong type",
+			policy: `
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "BalancePodsOnNodeForDefragmentation":
+    enabled: true
+    args:
+      iterations: "10"
+  "PodLifeTime":
+    enabled: true
+    args:
+      maxPodLifeTimeSecond: 86400
+`,
+			expectedErr: "[strategies[BalancePodsOnNodeForDefragmentation].args.iterations: Invalid value: \"string\": must be of type int32, strategies[PodLifeTime].args.maxPodLifeTimeSecond: Forbidden: unknown field]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			obj, err := runtime.Decode(scheme.Codecs.UniversalDecoder(), []byte(tc.policy))
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("Expected error %q, got %v, %+v", tc.expectedErr, err, obj)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unable to decode policy: %v", err)
+			}
+			if got := obj.(*api.DeschedulerPolicy).Strategies; !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected strategies %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestV1alpha2DefaultParams(t *testing.T) {
+	gracePeriodSeconds := int64(30)
+	policy := decodePolicy(t, []byte(`
+apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+strategies:
+  "RemovePodsViolatingNodeAffinity":
+    enabled: true
+    evictionGracePeriodSeconds: 30
+`))
+	registry.SetDefaultParams(policy)
+
+	// the grace period set on the strategy does not leave the strategy without its default node affinity type
+	expected := api.StrategyList{
+		"RemovePodsViolatingNodeAffinity": {
+			Enabled: true,
+			Params: &api.StrategyParameters{
+				NodeAffinityType:           []string{"requiredDuringSchedulingIgnoredDuringExecution"},
+				EvictionGracePeriodSeconds: &gracePeriodSeconds,
+			},
+		},
+	}
+	if !reflect.DeepEqual(policy.Strategies, expected) {
+		t.Errorf("Expected strategies %+v, got %+v", expected, policy.Strategies)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerPolicy configures the strategies run by the descheduler. Unlike in v1alpha1,
+// every strategy is configured through the args type of its own.
+type DeschedulerPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Strategies
+	Strategies StrategyList `json:"strategies,omitempty"`
+
+	// NodeSelector for a set of nodes to operate over
+	NodeSelector *string `json:"nodeSelector,omitempty"`
+
+	// EvictLocalStoragePods allows pods using local storage to be evicted.
+	EvictLocalStoragePods *bool `json:"evictLocalStoragePods,omitempty"`
+
+	// EvictSystemCriticalPods allows eviction of pods of any priority (including Kubernetes system pods)
+	EvictSystemCriticalPods *bool `json:"evictSystemCriticalPods,omitempty"`
+
+	// IgnorePVCPods prevents pods with PVCs from being evicted.
+	IgnorePVCPods *bool `json:"ignorePvcPods,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
+	MaxNoOfPodsToEvictPerNode *int `json:"maxNoOfPodsToEvictPerNode,omitempty"`
+
+	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
+	MaxNoOfPodsToEvictPerNamespace *int `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+
+	// MaxNoOfPodsToEvictTotal restricts maximum of pods to be evicted per descheduling cycle.
+	MaxNoOfPodsToEvictTotal *int `json:"maxNoOfPodsToEvictTotal,omitempty"`
+
+	// EvictionGracePeriodSeconds sets the termination grace period of the evicted pods.
+	// The grace period of the pod is used when not set.
+	EvictionGracePeriodSeconds *int64 `json:"evictionGracePeriodSeconds,omitempty"`
+
+	// PropagationPolicy sets whether and how garbage collection is performed when pods are evicted.
+	PropagationPolicy *metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+
+	// EvictionQPS limits the number of evictions per second. Evictions are not rate limited when not set.
+	EvictionQPS *float32 `json:"evictionQPS,omitempty"`
+
+	// EvictionBurst is the maximum burst of evictions allowed on top of EvictionQPS. Defaults to 1.
+	EvictionBurst *int `json:"evictionBurst,omitempty"`
+
+	// MaxEvictionRetries is the number of times an eviction rejected with 429 Too Many Requests
+	// is retried during the same descheduling cycle. Rejected evictions are not retried when not set.
+	MaxEvictionRetries *int `json:"maxEvictionRetries,omitempty"`
+
+	// MinClusterSize is the minimum number of ready nodes the descheduling cycles run with, a cycle being
+	// skipped when fewer nodes are ready. Defaults to 2.
+	MinClusterSize *int `json:"minClusterSize,omitempty"`
+
+	// NodeConcurrency is the number of nodes the strategies evaluating the nodes one at a time
+	// evaluate in parallel. Defaults to 1.
+	NodeConcurrency *int `json:"nodeConcurrency,omitempty"`
+}
+
+type StrategyName string
+type StrategyList map[StrategyName]DeschedulerStrategy
+
+type DeschedulerStrategy struct {
+	// Enabled or disabled
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Weight orders the strategies, the heaviest runs first. It also sets the share of
+	// MaxNoOfPodsToEvictPerNode and MaxNoOfPodsToEvictTotal the strategy can use.
+	Weight int `json:"weight,omitempty"`
+
+	// EvictionGracePeriodSeconds overrides the grace period of the policy for the pods evicted by the strategy.
+	EvictionGracePeriodSeconds *int64 `json:"evictionGracePeriodSeconds,omitempty"`
+
+	// SortBy names the sorters ordering the pods the strategy evicts, the pods the first sorter
+	// does not tell apart being ordered by the second one and so on.
+	SortBy []string `json:"sortBy,omitempty"`
+
+	// Args holds the parameters of the strategy, of the args type named after the strategy,
+	// e.g. PodLifeTimeArgs for the PodLifeTime strategy.
+	Args runtime.RawExtension `json:"args,omitempty"`
+}
+
+// Namespaces carries a list of included/excluded namespaces
+// for which a given strategy is applicable.
+type Namespaces struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+type Percentage float64
+type ResourceThresholds map[v1.ResourceName]Percentage
+
+// RemoveDuplicatesArgs holds the parameters of the RemoveDuplicates strategy
+type RemoveDuplicatesArgs struct {
+	Namespaces                 *Namespaces `json:"namespaces,omitempty"`
+	ThresholdPriority          *int32      `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string      `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool        `json:"nodeFit,omitempty"`
+	ExcludeOwnerKinds          []string    `json:"excludeOwnerKinds,omitempty"`
+}
+
+// LowNodeUtilizationArgs holds the parameters of the LowNodeUtilization strategy
+type LowNodeUtilizationArgs struct {
+	ThresholdPriority          *int32             `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string             `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool               `json:"nodeFit,omitempty"`
+	Thresholds                 ResourceThresholds `json:"thresholds,omitempty"`
+	TargetThresholds           ResourceThresholds `json:"targetThresholds,omitempty"`
+	NumberOfNodes              int                `json:"numberOfNodes,omitempty"`
+	UsageSource                string             `json:"usageSource,omitempty"`
+	MetricsWindowSeconds       *uint              `json:"metricsWindowSeconds,omitempty"`
+	UseDeviationThresholds     bool               `json:"useDeviationThresholds,omitempty"`
+}
+
+// HighNodeUtilizationArgs holds the parameters of the HighNodeUtilization strategy
+type HighNodeUtilizationArgs struct {
+	ThresholdPriority          *int32             `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string             `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool               `json:"nodeFit,omitempty"`
+	Thresholds                 ResourceThresholds `json:"thresholds,omitempty"`
+	NumberOfNodes              int                `json:"numberOfNodes,omitempty"`
+	UsageSource                string             `json:"usageSource,omitempty"`
+	MetricsWindowSeconds       *uint              `json:"metricsWindowSeconds,omitempty"`
+}
+
+// RemovePodsViolatingInterPodAntiAffinityArgs holds the parameters of the RemovePodsViolatingInterPodAntiAffinity strategy
+type RemovePodsViolatingInterPodAntiAffinityArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+}
+
+// RemovePodsViolatingNodeAffinityArgs holds the parameters of the RemovePodsViolatingNodeAffinity strategy
+type RemovePodsViolatingNodeAffinityArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+	NodeAffinityType           []string              `json:"nodeAffinityType,omitempty"`
+}
+
+// RemovePodsViolatingNodeTaintsArgs holds the parameters of the RemovePodsViolatingNodeTaints strategy
+type RemovePodsViolatingNodeTaintsArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+}
+
+// RemovePodsViolatingTopologySpreadConstraintArgs holds the parameters of the RemovePodsViolatingTopologySpreadConstraint strategy
+type RemovePodsViolatingTopologySpreadConstraintArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+	IncludeSoftConstraints     bool                  `json:"includeSoftConstraints,omitempty"`
+}
+
+// RemovePodsHavingTooManyRestartsArgs holds the parameters of the RemovePodsHavingTooManyRestarts strategy
+type RemovePodsHavingTooManyRestartsArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+	PodRestartThreshold        int32                 `json:"podRestartThreshold,omitempty"`
+	IncludingInitContainers    bool                  `json:"includingInitContainers,omitempty"`
+}
+
+// PodLifeTimeArgs holds the parameters of the PodLifeTime strategy
+type PodLifeTimeArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	MaxPodLifeTimeSeconds      *uint                 `json:"maxPodLifeTimeSeconds,omitempty"`
+	PodStatusPhases            []string              `json:"podStatusPhases,omitempty"`
+}
+
+// RemoveFailedPodsArgs holds the parameters of the RemoveFailedPods strategy
+type RemoveFailedPodsArgs struct {
+	Namespaces                 *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector              *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	ThresholdPriority          *int32                `json:"thresholdPriority,omitempty"`
+	ThresholdPriorityClassName string                `json:"thresholdPriorityClassName,omitempty"`
+	NodeFit                    bool                  `json:"nodeFit,omitempty"`
+	ExcludeOwnerKinds          []string              `json:"excludeOwnerKinds,omitempty"`
+	MinPodLifetimeSeconds      *uint                 `json:"minPodLifetimeSeconds,omitempty"`
+	Reasons                    []string              `json:"reasons,omitempty"`
+	IncludingInitContainers    bool                  `json:"includingInitContainers,omitempty"`
+}
+
+// BalancePodsOnNodeForDefragmentationArgs holds the parameters of the BalancePodsOnNodeForDefragmentation strategy
+type BalancePodsOnNodeForDefragmentationArgs struct {
+	Iterations *int32 `json:"iterations,omitempty"`
+}
+
+// PlacePodsOnNodeForDefragmentationArgs holds the parameters of the PlacePodsOnNodeForDefragmentation strategy
+type PlacePodsOnNodeForDefragmentationArgs struct {
+	Namespaces    *Namespaces           `json:"namespaces,omitempty"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	Iterations    *int32                `json:"iterations,omitempty"`
+}
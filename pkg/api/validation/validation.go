@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
+)
+
+// ValidateDeschedulerPolicy validates the policy, including the parameters of every enabled strategy.
+// Only the checks not requiring to reach the cluster are run, e.g. thresholdPriorityClassName is not resolved.
+func ValidateDeschedulerPolicy(policy *api.DeschedulerPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if policy.NodeSelector != nil {
+		if _, err := labels.Parse(*policy.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("nodeSelector"), *policy.NodeSelector, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateNonNegativeInt(policy.MaxNoOfPodsToEvictPerNode, field.NewPath("maxNoOfPodsToEvictPerNode"))...)
+	allErrs = append(allErrs, validateNonNegativeInt(policy.MaxNoOfPodsToEvictPerNamespace, field.NewPath("maxNoOfPodsToEvictPerNamespace"))...)
+	allErrs = append(allErrs, validateNonNegativeInt(policy.MaxNoOfPodsToEvictTotal, field.NewPath("maxNoOfPodsToEvictTotal"))...)
+	allErrs = append(allErrs, validateGracePeriodSeconds(policy.EvictionGracePeriodSeconds, field.NewPath("evictionGracePeriodSeconds"))...)
+	if policy.PropagationPolicy != nil {
+		switch *policy.PropagationPolicy {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+		default:
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("propagationPolicy"), *policy.PropagationPolicy, []string{
+				string(metav1.DeletePropagationOrphan), string(metav1.DeletePropagationBackground), string(metav1.DeletePropagationForeground),
+			}))
+		}
+	}
+	if policy.EvictionQPS != nil && *policy.EvictionQPS < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("evictionQPS"), *policy.EvictionQPS, "must not be negative"))
+	}
+	if policy.EvictionBurst != nil && *policy.EvictionBurst < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("evictionBurst"), *policy.EvictionBurst, "must be positive"))
+	}
+	allErrs = append(allErrs, validateNonNegativeInt(policy.MaxEvictionRetries, field.NewPath("maxEvictionRetries"))...)
+	allErrs = append(allErrs, validateNonNegativeInt(policy.MinClusterSize, field.NewPath("minClusterSize"))...)
+	if policy.NodeConcurrency != nil && *policy.NodeConcurrency < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("nodeConcurrency"), *policy.NodeConcurrency, "must be positive"))
+	}
+
+	// the strategies are validated in alphabetical order for the errors to be reported in a stable order
+	names := make([]string, 0, len(policy.Strategies))
+	for name := range policy.Strategies {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		allErrs = append(allErrs, validateStrategy(api.StrategyName(name), policy.Strategies[api.StrategyName(name)], field.NewPath("strategies").Key(name))...)
+	}
+
+	return allErrs
+}
+
+// validateStrategy validates a strategy of the policy. The parameters of disabled strategies are not validated.
+func validateStrategy(name api.StrategyName, strategy api.DeschedulerStrategy, fldPath *field.Path) field.ErrorList {
+	registered, ok := registry.Lookup(name)
+	if !ok {
+		return field.ErrorList{field.NotSupported(fldPath, name, registry.Names())}
+	}
+	if !strategy.Enabled {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if strategy.Weight < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("weight"), strategy.Weight, "must not be negative"))
+	}
+	paramsPath := fldPath.Child("params")
+	if strategy.Params != nil {
+		allErrs = append(allErrs, validateCommonParams(strategy.Params, paramsPath)...)
+		if len(strategy.Params.SortBy) > 0 && !registered.SupportsSortBy {
+			allErrs = append(allErrs, field.Forbidden(paramsPath.Child("sortBy"), "not supported by the strategy"))
+		}
+	}
+	if registered.ValidateParams != nil {
+		allErrs = append(allErrs, registered.ValidateParams(strategy.Params, paramsPath)...)
+	}
+	return allErrs
+}
+
+// validateCommonParams validates the parameters shared by the strategies
+func validateCommonParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if params.Namespaces != nil && len(params.Namespaces.Include) > 0 && len(params.Namespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("namespaces"), "only one of include and exclude can be set"))
+	}
+	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("thresholdPriorityClassName"), "only one of thresholdPriority and thresholdPriorityClassName can be set"))
+	}
+	if params.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(params.LabelSelector, fldPath.Child("labelSelector"))...)
+	}
+	sorters := sets.NewString(framework.SorterNames()...)
+	for i, name := range params.SortBy {
+		if !sorters.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("sortBy").Index(i), name, sorters.List()))
+		}
+	}
+	return append(allErrs, validateGracePeriodSeconds(params.EvictionGracePeriodSeconds, fldPath.Child("evictionGracePeriodSeconds"))...)
+}
+
+func validateNonNegativeInt(value *int, fldPath *field.Path) field.ErrorList {
+	if value != nil && *value < 0 {
+		return field.ErrorList{field.Invalid(fldPath, *value, "must not be negative")}
+	}
+	return nil
+}
+
+func validateGracePeriodSeconds(gracePeriodSeconds *int64, fldPath *field.Path) field.ErrorList {
+	if gracePeriodSeconds != nil && *gracePeriodSeconds < 0 {
+		return field.ErrorList{field.Invalid(fldPath, *gracePeriodSeconds, "must not be negative")}
+	}
+	return nil
+}
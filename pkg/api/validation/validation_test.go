@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateDeschedulerPolicy(t *testing.T) {
+	negative := -1
+	negativeGracePeriod := int64(-1)
+	nodeSelector := "node-role.kubernetes.io/worker in (,"
+	unknownPropagationPolicy := metav1.DeletionPropagation("Unknown")
+	priority := int32(1000)
+	maxPodLifeTimeSeconds := uint(3600)
+	negativeIterations := int32(-1)
+	metricsWindowSeconds := uint(300)
+
+	tests := []struct {
+		description string
+		policy      *api.DeschedulerPolicy
+		errors      []string
+	}{
+		{
+			description: "valid policy",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{
+					"PodLifeTime": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds, PodStatusPhases: []string{"Pending"}},
+							Namespaces:  &api.Namespaces{Exclude: []string{"kube-system"}},
+							SortBy:      []string{"Oldest"},
+						},
+					},
+					"LowNodeUtilization": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+								Thresholds:           api.ResourceThresholds{"cpu": 20, "memory": 20},
+								TargetThresholds:     api.ResourceThresholds{"cpu": 50, "memory": 50},
+								UsageSource:          "metrics",
+								MetricsWindowSeconds: &metricsWindowSeconds,
+							},
+						},
+					},
+					"BalancePodsOnNodeForDefragmentation": {Enabled: true},
+					// the parameters of disabled strategies are not validated
+					"RemovePodsHavingTooManyRestarts": {Enabled: false},
+				},
+			},
+		},
+		{
+			// as deviations from the average utilization, thresholds can be greater than targetThresholds
+			description: "valid deviation thresholds",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{
+					"LowNodeUtilization": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+								Thresholds:             api.ResourceThresholds{"cpu": 20},
+								TargetThresholds:       api.ResourceThresholds{"cpu": 10},
+								UseDeviationThresholds: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "invalid policy settings",
+			policy: &api.DeschedulerPolicy{
+				NodeSelector:               &nodeSelector,
+				MaxNoOfPodsToEvictPerNode:  &negative,
+				EvictionGracePeriodSeconds: &negativeGracePeriod,
+				PropagationPolicy:          &unknownPropagationPolicy,
+				MinClusterSize:             &negative,
+				NodeConcurrency:            &negative,
+			},
+			errors: []string{
+				"nodeSelector: Invalid value",
+				"maxNoOfPodsToEvictPerNode: Invalid value: -1: must not be negative",
+				"evictionGracePeriodSeconds: Invalid value: -1: must not be negative",
+				`propagationPolicy: Unsupported value: "Unknown"`,
+				"minClusterSize: Invalid value: -1: must not be negative",
+				"nodeConcurrency: Invalid value: -1: must be positive",
+			},
+		},
+		{
+			description: "unknown strategy",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{"RemoveDuplicate": {Enabled: false}},
+			},
+			errors: []string{`strategies[RemoveDuplicate]: Unsupported value: "RemoveDuplicate"`},
+		},
+		{
+			description: "invalid common parameters",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{
+					"RemoveDuplicates": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							Namespaces:                 &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+							ThresholdPriority:          &priority,
+							ThresholdPriorityClassName: "high-priority",
+							LabelSelector:              &metav1.LabelSelector{MatchLabels: map[string]string{"app": "-invalid-"}},
+							EvictionGracePeriodSeconds: &negativeGracePeriod,
+							SortBy:                     []string{"Priority", "Newest"},
+						},
+					},
+					"BalancePodsOnNodeForDefragmentation": {
+						Enabled: true,
+						Params:  &api.StrategyParameters{SortBy: []string{"Oldest"}},
+					},
+				},
+			},
+			errors: []string{
+				"strategies[BalancePodsOnNodeForDefragmentation].params.sortBy: Forbidden: not supported by the strategy",
+				"strategies[RemoveDuplicates].params.namespaces: Forbidden: only one of include and exclude can be set",
+				"strategies[RemoveDuplicates].params.thresholdPriorityClassName: Forbidden: only one of thresholdPriority and thresholdPriorityClassName can be set",
+				"strategies[RemoveDuplicates].params.labelSelector.matchLabels: Invalid value",
+				"strategies[RemoveDuplicates].params.sortBy[1]: Unsupported value: \"Newest\"",
+				"strategies[RemoveDuplicates].params.evictionGracePeriodSeconds: Invalid value: -1: must not be negative",
+			},
+		},
+		{
+			description: "missing strategy parameters",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{
+					"LowNodeUtilization":              {Enabled: true},
+					"RemovePodsViolatingNodeAffinity": {Enabled: true},
+					"RemovePodsHavingTooManyRestarts": {Enabled: true, Params: &api.StrategyParameters{PodsHavingTooManyRestarts: &api.PodsHavingTooManyRestarts{}}},
+					"PodLifeTime":                     {Enabled: true, Params: &api.StrategyParameters{PodLifeTime: &api.PodLifeTime{}}},
+				},
+			},
+			errors: []string{
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds: Required value",
+				"strategies[PodLifeTime].params.podLifeTime.maxPodLifeTimeSeconds: Required value",
+				"strategies[RemovePodsHavingTooManyRestarts].params.podsHavingTooManyRestarts.podRestartThreshold: Invalid value: 0: must be positive",
+				"strategies[RemovePodsViolatingNodeAffinity].params.nodeAffinityType: Required value",
+			},
+		},
+		{
+			description: "invalid strategy parameters",
+			policy: &api.DeschedulerPolicy{
+				Strategies: api.StrategyList{
+					"LowNodeUtilization": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+								Thresholds:           api.ResourceThresholds{"cpu": 60, "memory": 120},
+								TargetThresholds:     api.ResourceThresholds{"cpu": 50, "pods": 50},
+								MetricsWindowSeconds: &metricsWindowSeconds,
+							},
+						},
+					},
+					"HighNodeUtilization": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+								Thresholds:             api.ResourceThresholds{"cpu": 20},
+								TargetThresholds:       api.ResourceThresholds{"cpu": 50},
+								UsageSource:            "limits",
+								UseDeviationThresholds: true,
+							},
+						},
+					},
+					"PodLifeTime": {
+						Enabled: true,
+						Params: &api.StrategyParameters{
+							PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds, PodStatusPhases: []string{"Succeeded"}},
+						},
+					},
+					"PlacePodsOnNodeForDefragmentation": {
+						Enabled: true,
+						Params:  &api.StrategyParameters{Iterations: &negativeIterations},
+					},
+				},
+			},
+			errors: []string{
+				"strategies[HighNodeUtilization].params.nodeResourceUtilizationThresholds.targetThresholds: Forbidden: not applicable to HighNodeUtilization",
+				"strategies[HighNodeUtilization].params.nodeResourceUtilizationThresholds.useDeviationThresholds: Forbidden: not applicable to HighNodeUtilization",
+				`strategies[HighNodeUtilization].params.nodeResourceUtilizationThresholds.usageSource: Unsupported value: "limits"`,
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds.thresholds[memory]: Invalid value: 120: must be in the [0, 100] range",
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds.thresholds[cpu]: Invalid value: 60: must not be greater than the targetThresholds one",
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds.targetThresholds[memory]: Required value: thresholds and targetThresholds must configure the same resources",
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds.thresholds[pods]: Required value: thresholds and targetThresholds must configure the same resources",
+				"strategies[LowNodeUtilization].params.nodeResourceUtilizationThresholds.metricsWindowSeconds: Forbidden: only applicable to the metrics usage source",
+				"strategies[PlacePodsOnNodeForDefragmentation].params.iterations: Invalid value: -1: must not be negative",
+				`strategies[PodLifeTime].params.podLifeTime.podStatusPhases[0]: Unsupported value: "Succeeded"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			errs := ValidateDeschedulerPolicy(tc.policy)
+			var got []string
+			for i, err := range errs {
+				// only the prefixes of the errors are checked, the details of some being tedious to spell out
+				if i < len(tc.errors) && strings.HasPrefix(err.Error(), tc.errors[i]) {
+					got = append(got, tc.errors[i])
+				} else {
+					got = append(got, err.Error())
+				}
+			}
+			if !reflect.DeepEqual(got, tc.errors) {
+				t.Errorf("Expected errors %q, got %q", tc.errors, got)
+			}
+		})
+	}
+}
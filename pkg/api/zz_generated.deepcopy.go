@@ -61,6 +61,51 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxNoOfPodsToEvictPerNamespace != nil {
+		in, out := &in.MaxNoOfPodsToEvictPerNamespace, &out.MaxNoOfPodsToEvictPerNamespace
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxNoOfPodsToEvictTotal != nil {
+		in, out := &in.MaxNoOfPodsToEvictTotal, &out.MaxNoOfPodsToEvictTotal
+		*out = new(int)
+		**out = **in
+	}
+	if in.EvictionGracePeriodSeconds != nil {
+		in, out := &in.EvictionGracePeriodSeconds, &out.EvictionGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PropagationPolicy != nil {
+		in, out := &in.PropagationPolicy, &out.PropagationPolicy
+		*out = new(v1.DeletionPropagation)
+		**out = **in
+	}
+	if in.EvictionQPS != nil {
+		in, out := &in.EvictionQPS, &out.EvictionQPS
+		*out = new(float32)
+		**out = **in
+	}
+	if in.EvictionBurst != nil {
+		in, out := &in.EvictionBurst, &out.EvictionBurst
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxEvictionRetries != nil {
+		in, out := &in.MaxEvictionRetries, &out.MaxEvictionRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinClusterSize != nil {
+		in, out := &in.MinClusterSize, &out.MinClusterSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.NodeConcurrency != nil {
+		in, out := &in.NodeConcurrency, &out.NodeConcurrency
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -82,6 +127,36 @@ func (in *DeschedulerPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeschedulerPolicyStatus) DeepCopyInto(out *DeschedulerPolicyStatus) {
+	*out = *in
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make([]StrategyStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidationErrors != nil {
+		in, out := &in.ValidationErrors, &out.ValidationErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulerPolicyStatus.
+func (in *DeschedulerPolicyStatus) DeepCopy() *DeschedulerPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulerPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerStrategy) DeepCopyInto(out *DeschedulerStrategy) {
 	*out = *in
@@ -177,6 +252,11 @@ func (in *NodeResourceUtilizationThresholds) DeepCopyInto(out *NodeResourceUtili
 			(*out)[key] = val
 		}
 	}
+	if in.MetricsWindowSeconds != nil {
+		in, out := &in.MetricsWindowSeconds, &out.MetricsWindowSeconds
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
@@ -345,6 +425,21 @@ func (in *StrategyParameters) DeepCopyInto(out *StrategyParameters) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Iterations != nil {
+		in, out := &in.Iterations, &out.Iterations
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EvictionGracePeriodSeconds != nil {
+		in, out := &in.EvictionGracePeriodSeconds, &out.EvictionGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SortBy != nil {
+		in, out := &in.SortBy, &out.SortBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -357,3 +452,19 @@ func (in *StrategyParameters) DeepCopy() *StrategyParameters {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StrategyStatus) DeepCopyInto(out *StrategyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StrategyStatus.
+func (in *StrategyStatus) DeepCopy() *StrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -38,6 +38,10 @@ type DeschedulerConfiguration struct {
 	// PolicyConfigFile is the filepath to the descheduler policy configuration.
 	PolicyConfigFile string `json:"policyConfigFile,omitempty"`
 
+	// PolicyName is the name of the cluster scoped DeschedulerPolicy object the policy is read from,
+	// instead of PolicyConfigFile.
+	PolicyName string `json:"policyName,omitempty"`
+
 	// Dry run
 	DryRun bool `json:"dryRun,omitempty"`
 
@@ -53,6 +57,20 @@ type DeschedulerConfiguration struct {
 	// IgnorePVCPods sets whether PVC pods should be allowed to be evicted
 	IgnorePVCPods bool `json:"ignorePvcPods,omitempty"`
 
+	// LeaderElection starts Deployment using leader election loop
+	LeaderElection componentbaseconfig.LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+
+	// AuditLogPath is the file the audit records of the evictions are appended to, "-" means standard output.
+	// No audit records are written when empty.
+	AuditLogPath string `json:"auditLogPath,omitempty"`
+
+	// MaxCycleReports is the number of reports of the last descheduling cycles kept, 0 means none.
+	MaxCycleReports int `json:"maxCycleReports,omitempty"`
+
+	// LivenessIntervalMultiplier is the number of descheduling intervals the descheduling loop may go without
+	// completing a cycle before the liveness check fails, 0 disables the check.
+	LivenessIntervalMultiplier int `json:"livenessIntervalMultiplier,omitempty"`
+
 	// Logging specifies the options of logging.
 	// Refer [Logs Options](https://github.com/kubernetes/component-base/blob/master/logs/options.go) for more information.
 	Logging componentbaseconfig.LoggingConfiguration `json:"logging,omitempty"`
@@ -52,11 +52,16 @@ func autoConvert_v1alpha1_DeschedulerConfiguration_To_componentconfig_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyName = in.PolicyName
 	out.DryRun = in.DryRun
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
+	out.LeaderElection = in.LeaderElection
+	out.AuditLogPath = in.AuditLogPath
+	out.MaxCycleReports = in.MaxCycleReports
+	out.LivenessIntervalMultiplier = in.LivenessIntervalMultiplier
 	out.Logging = in.Logging
 	return nil
 }
@@ -70,11 +75,16 @@ func autoConvert_componentconfig_DeschedulerConfiguration_To_v1alpha1_Deschedule
 	out.DeschedulingInterval = time.Duration(in.DeschedulingInterval)
 	out.KubeconfigFile = in.KubeconfigFile
 	out.PolicyConfigFile = in.PolicyConfigFile
+	out.PolicyName = in.PolicyName
 	out.DryRun = in.DryRun
 	out.NodeSelector = in.NodeSelector
 	out.MaxNoOfPodsToEvictPerNode = in.MaxNoOfPodsToEvictPerNode
 	out.EvictLocalStoragePods = in.EvictLocalStoragePods
 	out.IgnorePVCPods = in.IgnorePVCPods
+	out.LeaderElection = in.LeaderElection
+	out.AuditLogPath = in.AuditLogPath
+	out.MaxCycleReports = in.MaxCycleReports
+	out.LivenessIntervalMultiplier = in.LivenessIntervalMultiplier
 	out.Logging = in.Logging
 	return nil
 }
@@ -28,6 +28,7 @@ import (
 func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	out.LeaderElection = in.LeaderElection
 	out.Logging = in.Logging
 	return
 }
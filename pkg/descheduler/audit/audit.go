@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit keeps a durable record of the pods touched by the descheduler
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record describes a single pod the descheduler evicted, deleted or tried to
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Strategy  string    `json:"strategy"`
+	Reason    string    `json:"reason,omitempty"`
+	// Pod is the namespace/name of the pod
+	Pod  string `json:"pod"`
+	Node string `json:"node,omitempty"`
+	// Owner is the kind/name of the controller of the pod
+	Owner  string `json:"owner,omitempty"`
+	DryRun bool   `json:"dryRun"`
+	// Result is "success", "error" or the reason the pod was not evicted, as reported by the pods_evicted metric
+	Result string `json:"result"`
+}
+
+// Sink stores audit records
+type Sink interface {
+	Write(record Record) error
+	Close() error
+}
+
+// jsonLinesSink writes every record as a JSON object on its own line
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+	// file is the file the records are written to, nil when the sink does not own w or is closed
+	file *os.File
+}
+
+var _ Sink = &jsonLinesSink{}
+
+// NewJSONLinesSink writes the records to w as JSON lines. Closing the sink does not close w.
+func NewJSONLinesSink(w io.Writer) Sink {
+	return &jsonLinesSink{w: w}
+}
+
+// NewFileSink appends the records to the file at path as JSON lines, creating the file if needed.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log %q: %v", path, err)
+	}
+	return &jsonLinesSink{w: f, file: f}, nil
+}
+
+// NewSink creates the sink for path, "-" being the standard output
+func NewSink(path string) (Sink, error) {
+	if path == "-" {
+		return NewJSONLinesSink(os.Stdout), nil
+	}
+	return NewFileSink(path)
+}
+
+func (s *jsonLinesSink) Write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close waits for the record being written, if any, then flushes the records to the disk and closes the file.
+// The records written once the sink is closed are rejected.
+func (s *jsonLinesSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	f := s.file
+	s.file = nil
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to flush audit log %q: %v", f.Name(), err)
+	}
+	return f.Close()
+}
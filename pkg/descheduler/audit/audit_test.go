@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	records := []Record{
+		{
+			Timestamp: time.Date(2021, 8, 1, 10, 0, 0, 0, time.UTC),
+			Strategy:  "PodLifeTime",
+			Reason:    "PodLifeTime",
+			Pod:       "default/p1",
+			Node:      "node1",
+			Owner:     "ReplicaSet/rs1",
+			Result:    "success",
+		},
+		{
+			Timestamp: time.Date(2021, 8, 1, 10, 0, 1, 0, time.UTC),
+			Strategy:  "RemoveDuplicates",
+			Pod:       "default/p2",
+			DryRun:    true,
+			Result:    "maximum number reached",
+		},
+	}
+
+	// the records are appended to the existing ones
+	for _, record := range records {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatalf("Unable to create sink: %v", err)
+		}
+		if err := sink.Write(record); err != nil {
+			t.Fatalf("Unable to write record: %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Unable to close sink: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unable to open audit log: %v", err)
+	}
+	defer f.Close()
+
+	var got []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Unable to decode %q: %v", scanner.Text(), err)
+		}
+		got = append(got, record)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("Expected records %+v, got %+v", records, got)
+	}
+}
+
+func TestFileSinkClosed(t *testing.T) {
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("Unable to create sink: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unable to close sink: %v", err)
+	}
+	if err := sink.Write(Record{Strategy: "PodLifeTime", Pod: "default/p1", Result: "success"}); err == nil {
+		t.Errorf("Expected the record written once the sink is closed to be rejected")
+	}
+	if err := sink.Close(); err != nil {
+		t.Errorf("Expected closing the sink again to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// skippedCycleInitialBackoff is the delay before retrying a skipped descheduling cycle, doubled on every
+// further skipped cycle up to the descheduling interval
+const skippedCycleInitialBackoff = 10 * time.Second
+
+// cycleBackoff runs the descheduling cycles every interval, retrying the cycles skipped because the ready
+// nodes could not be listed or were too few with an exponential backoff instead. It implements wait.BackoffManager.
+type cycleBackoff struct {
+	clock    clock.Clock
+	interval time.Duration
+	// delay is the delay before retrying the last cycle skipped, 0 when the last cycle ran
+	delay time.Duration
+}
+
+func newCycleBackoff(interval time.Duration) *cycleBackoff {
+	return &cycleBackoff{clock: clock.RealClock{}, interval: interval}
+}
+
+// skip records a skipped cycle, returning the delay before it is retried
+func (b *cycleBackoff) skip() time.Duration {
+	if b.delay == 0 {
+		b.delay = skippedCycleInitialBackoff
+	} else {
+		b.delay *= 2
+	}
+	if b.delay > b.interval {
+		b.delay = b.interval
+	}
+	return b.delay
+}
+
+// reset records a cycle ran, the next one running after the interval
+func (b *cycleBackoff) reset() {
+	b.delay = 0
+}
+
+// Backoff gives the timer of the next cycle
+func (b *cycleBackoff) Backoff() clock.Timer {
+	if b.delay > 0 {
+		return b.clock.NewTimer(b.delay)
+	}
+	return b.clock.NewTimer(b.interval)
+}
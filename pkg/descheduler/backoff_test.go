@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCycleBackoff(t *testing.T) {
+	backoff := newCycleBackoff(time.Minute)
+	var delays []time.Duration
+	for i := 0; i < 4; i++ {
+		delays = append(delays, backoff.skip())
+	}
+	expected := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute}
+	for i := range expected {
+		if delays[i] != expected[i] {
+			t.Fatalf("Expected the skipped cycles to be retried after %v, got %v", expected, delays)
+		}
+	}
+
+	backoff.reset()
+	if delay := backoff.skip(); delay != skippedCycleInitialBackoff {
+		t.Errorf("Expected the backoff to restart from %v once a cycle ran, got %v", skippedCycleInitialBackoff, delay)
+	}
+
+	short := newCycleBackoff(time.Second)
+	if delay := short.skip(); delay != time.Second {
+		t.Errorf("Expected the backoff to be capped by the interval, got %v", delay)
+	}
+}
@@ -19,7 +19,9 @@ package client
 import (
 	"fmt"
 
+	"k8s.io/client-go/dynamic"
 	clientset "k8s.io/client-go/kubernetes"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 	// Ensure to load all auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
@@ -27,6 +29,32 @@ import (
 )
 
 func CreateClient(kubeconfig string) (clientset.Interface, error) {
+	cfg, err := createConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return clientset.NewForConfig(cfg)
+}
+
+// CreateDynamicClient creates a client for the custom resources, DeschedulerPolicy objects among them
+func CreateDynamicClient(kubeconfig string) (dynamic.Interface, error) {
+	cfg, err := createConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+// CreateMetricsClient creates a client for the metrics.k8s.io API, reporting the resource usage of nodes and pods
+func CreateMetricsClient(kubeconfig string) (metricsclientset.Interface, error) {
+	cfg, err := createConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return metricsclientset.NewForConfig(cfg)
+}
+
+func createConfig(kubeconfig string) (*rest.Config, error) {
 	var cfg *rest.Config
 	if len(kubeconfig) != 0 {
 		master, err := GetMasterFromKubeconfig(kubeconfig)
@@ -47,7 +75,7 @@ func CreateClient(kubeconfig string) (clientset.Interface, error) {
 		}
 	}
 
-	return clientset.NewForConfig(cfg)
+	return cfg, nil
 }
 
 func GetMasterFromKubeconfig(filename string) (string, error) {
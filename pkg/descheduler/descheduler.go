@@ -19,152 +19,317 @@ package descheduler
 import (
 	"context"
 	"fmt"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/nodeutilization"
+	"reflect"
+	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
-	clientset "k8s.io/client-go/kubernetes"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/util/flowcontrol"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
 )
 
-func Run(rs *options.DeschedulerServer) error {
+// defaultEvictionRetryBackoff is the delay before the first retry of an eviction rejected with 429 Too Many Requests
+const defaultEvictionRetryBackoff = time.Second
+
+// defaultMinClusterSize is the minimum number of ready nodes the cycles run with when the policy does not set it
+const defaultMinClusterSize = 2
+
+// Run runs the descheduler until ctx is cancelled, e.g. on SIGTERM, or a single iteration finished when
+// no interval is set. The strategy running when ctx is cancelled finishes before Run returns.
+func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	metrics.Register()
 
-	ctx := context.Background()
 	rsclient, err := client.CreateClient(rs.KubeconfigFile)
 	if err != nil {
 		return err
 	}
 	rs.Client = rsclient
 
-	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile)
+	rs.MetricsClient, err = client.CreateMetricsClient(rs.KubeconfigFile)
 	if err != nil {
 		return err
 	}
-	if deschedulerPolicy == nil {
-		return fmt.Errorf("deschedulerPolicy is nil")
+
+	deschedulerPolicy, err := LoadPolicy(ctx, rs)
+	if err != nil {
+		return err
 	}
 
 	evictionPolicyGroupVersion, err := eutils.SupportEviction(rs.Client)
 	if err != nil || len(evictionPolicyGroupVersion) == 0 {
 		return err
 	}
+	if rs.Health != nil {
+		rs.Health.SetEvictionSupported()
+	}
+
+	// a single broadcaster records the events of all the evictions
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(3)
+	eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: rs.Client.CoreV1().Events("")})
+	defer eventBroadcaster.Shutdown()
+	rs.EventRecorder = eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
+
+	if rs.AuditLogPath != "" {
+		auditSink, err := audit.NewSink(rs.AuditLogPath)
+		if err != nil {
+			return err
+		}
+		defer auditSink.Close()
+		rs.AuditSink = auditSink
+	}
+
+	runFn := func(ctx context.Context) error {
+		stopChannel := make(chan struct{})
+		defer close(stopChannel)
+		return RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, evictionPolicyGroupVersion, stopChannel)
+	}
+
+	if rs.LeaderElection.LeaderElect {
+		return NewLeaderElection(runFn, rs.Client, &rs.LeaderElection, ctx)
+	}
 
-	stopChannel := make(chan struct{})
-	return RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, evictionPolicyGroupVersion, stopChannel)
+	return runFn(ctx)
 }
 
-type strategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor)
+// LoadPolicy reads the policy from the DeschedulerPolicy object named by --policy-name if set,
+// from --policy-config-file otherwise. The dynamic client of rs is created for the object.
+func LoadPolicy(ctx context.Context, rs *options.DeschedulerServer) (*api.DeschedulerPolicy, error) {
+	var deschedulerPolicy *api.DeschedulerPolicy
+	var err error
+	if rs.PolicyName != "" {
+		rs.DynamicClient, err = client.CreateDynamicClient(rs.KubeconfigFile)
+		if err != nil {
+			return nil, err
+		}
+		deschedulerPolicy, err = LoadPolicyObject(ctx, rs.DynamicClient, rs.PolicyName)
+	} else {
+		deschedulerPolicy, err = LoadPolicyConfig(rs.PolicyConfigFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deschedulerPolicy == nil {
+		return nil, fmt.Errorf("deschedulerPolicy is nil")
+	}
+	return deschedulerPolicy, nil
+}
 
+// RunDeschedulerStrategies runs the enabled strategies every DeschedulingInterval until
+// either ctx is cancelled, stopChannel is closed or a single iteration finished when no
+// interval is set.
 func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, stopChannel chan struct{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChannel:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
 	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	// pods are shared by all strategies through the informer cache, indexed by the node they are assigned to
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+	if err := podutil.AddNodeNameIndexer(podInformer); err != nil {
+		return fmt.Errorf("unable to index pods by node name: %v", err)
+	}
 
-	// scheduled pod cache
-	//sharedInformerFactory.Core().V1().Pods().Informer().AddEventHandler(
-	//	cache.FilteringResourceEventHandler{
-	//		Handler: cache.ResourceEventHandlerFuncs{
-	//			AddFunc:    cache.addPodToCache,
-	//			UpdateFunc: sched.updatePodInCache,
-	//			DeleteFunc: sched.deletePodFromCache,
-	//		},
-	//	},
-	//)
-	//
-	//sharedInformerFactory.Core().V1().Nodes().Informer().AddEventHandler(
-	//	cache.ResourceEventHandlerFuncs{
-	//		AddFunc:    sched.addNodeToCache,
-	//		UpdateFunc: sched.updateNodeInCache,
-	//		DeleteFunc: sched.deleteNodeFromCache,
-	//	},
-	//)
-
-	sharedInformerFactory.Start(stopChannel)
-	sharedInformerFactory.WaitForCacheSync(stopChannel)
-
-	strategyFuncs := map[api.StrategyName]strategyFunction{
-		"RemoveDuplicates":                            strategies.RemoveDuplicatePods,
-		"LowNodeUtilization":                          nodeutilization.LowNodeUtilization,
-		"HighNodeUtilization":                         nodeutilization.HighNodeUtilization,
-		"RemovePodsViolatingInterPodAntiAffinity":     strategies.RemovePodsViolatingInterPodAntiAffinity,
-		"RemovePodsViolatingNodeAffinity":             strategies.RemovePodsViolatingNodeAffinity,
-		"RemovePodsViolatingNodeTaints":               strategies.RemovePodsViolatingNodeTaints,
-		"RemovePodsHavingTooManyRestarts":             strategies.RemovePodsHavingTooManyRestarts,
-		"PodLifeTime":                                 strategies.PodLifeTime,
-		"RemovePodsViolatingTopologySpreadConstraint": strategies.RemovePodsViolatingTopologySpreadConstraint,
-		"RemoveFailedPods":                            strategies.RemoveFailedPods,
-		"BalancePodsOnNodeForDefragmentation":	       defragmentation.BalancePodsOnNodeForDefragmentation,
-		"PlacePodsOnNodeForDefragmentation":	       defragmentation.PlacePodsOnNodeForDefragmentation,
-	}
-
-	nodeSelector := rs.NodeSelector
-	if deschedulerPolicy.NodeSelector != nil {
-		nodeSelector = *deschedulerPolicy.NodeSelector
+	var evictorOpts []evictions.EvictorOption
+	// policy/v1 PodDisruptionBudgets are served by every cluster serving policy/v1 evictions
+	if evictionPolicyGroupVersion == policyv1.SchemeGroupVersion.String() {
+		pdbLister := sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+		evictorOpts = append(evictorOpts, evictions.WithPodDisruptionBudgetLister(pdbLister))
+	}
+	if rs.EventRecorder != nil {
+		evictorOpts = append(evictorOpts, evictions.WithEventRecorder(rs.EventRecorder))
+	}
+	if rs.AuditSink != nil {
+		evictorOpts = append(evictorOpts, evictions.WithAuditSink(rs.AuditSink))
+	}
+	if rs.MetricsClient != nil {
+		evictorOpts = append(evictorOpts, evictions.WithMetricsClient(rs.MetricsClient))
 	}
 
-	evictLocalStoragePods := rs.EvictLocalStoragePods
-	if deschedulerPolicy.EvictLocalStoragePods != nil {
-		evictLocalStoragePods = *deschedulerPolicy.EvictLocalStoragePods
+	if rs.Health != nil {
+		rs.Health.SetInformersStarted()
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	synced := true
+	for _, ok := range sharedInformerFactory.WaitForCacheSync(ctx.Done()) {
+		synced = synced && ok
+	}
+	if synced && rs.Health != nil {
+		rs.Health.SetInformersSynced()
 	}
 
-	evictSystemCriticalPods := false
-	if deschedulerPolicy.EvictSystemCriticalPods != nil {
-		evictSystemCriticalPods = *deschedulerPolicy.EvictSystemCriticalPods
-		if evictSystemCriticalPods {
-			klog.V(1).InfoS("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
+	cycle, err := newCycleConfig(rs, deschedulerPolicy, evictorOpts)
+	if err != nil {
+		if rs.PolicyName != "" {
+			if err := updatePolicyStatus(ctx, rs.DynamicClient, rs.PolicyName, &api.DeschedulerPolicyStatus{ValidationErrors: []string{err.Error()}}); err != nil {
+				klog.ErrorS(err, "Unable to report policy validation errors")
+			}
 		}
+		return err
 	}
 
-	ignorePvcPods := false
-	if deschedulerPolicy.IgnorePVCPods != nil {
-		ignorePvcPods = *deschedulerPolicy.IgnorePVCPods
+	var watcher *policyWatcher
+	if rs.PolicyConfigFile != "" && rs.DeschedulingInterval.Seconds() != 0 {
+		watcher = newPolicyWatcher(rs.PolicyConfigFile, deschedulerPolicy, rs.EventRecorder)
+		go watcher.Run(ctx, policyReloadPeriod)
 	}
 
-	maxNoOfPodsToEvictPerNode := rs.MaxNoOfPodsToEvictPerNode
-	if deschedulerPolicy.MaxNoOfPodsToEvictPerNode != nil {
-		maxNoOfPodsToEvictPerNode = *deschedulerPolicy.MaxNoOfPodsToEvictPerNode
+	// policy and validation errors of the DeschedulerPolicy object last read
+	lastReadPolicy := deschedulerPolicy
+	var policyValidationErrors []string
+
+	if rs.Health != nil {
+		rs.Health.LoopStarted()
+		defer rs.Health.LoopStopped()
+	}
+
+	// a cycle is skipped when the ready nodes cannot be listed or are too few, to be retried with a backoff.
+	// Without an interval, the single cycle is skipped and the next run of the descheduler is the retry.
+	backoff := newCycleBackoff(rs.DeschedulingInterval)
+	skipCycle := func(reason string, err error) {
+		metrics.CyclesSkipped.With(map[string]string{"reason": reason}).Inc()
+		if rs.DeschedulingInterval.Seconds() == 0 {
+			klog.ErrorS(err, "Skipping the descheduling cycle", "reason", reason)
+			return
+		}
+		klog.ErrorS(err, "Skipping the descheduling cycle", "reason", reason, "retryAfter", backoff.skip())
 	}
 
-	wait.Until(func() {
-		nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, nodeSelector)
+	wait.BackoffUntil(func() {
+		// a reloaded policy is only picked up between cycles
+		if watcher != nil {
+			if policy := watcher.Policy(); policy != cycle.policy {
+				reloaded, err := newCycleConfig(rs, policy, evictorOpts)
+				if err != nil {
+					// validated by the policy watcher already
+					klog.ErrorS(err, "Unable to apply reloaded policy, keeping the previous one")
+				} else {
+					klog.V(1).InfoS("Applying reloaded policy", "file", rs.PolicyConfigFile)
+					cycle = reloaded
+				}
+			}
+		}
+
+		// the DeschedulerPolicy object is read again in every cycle, its status being reported once the cycle finished
+		var status *api.DeschedulerPolicyStatus
+		if rs.PolicyName != "" {
+			policy, err := LoadPolicyObject(ctx, rs.DynamicClient, rs.PolicyName)
+			if err != nil {
+				klog.ErrorS(err, "Unable to read policy, keeping the previous one")
+			} else if !reflect.DeepEqual(policy, lastReadPolicy) {
+				lastReadPolicy = policy
+				reloaded, err := newCycleConfig(rs, policy, evictorOpts)
+				if err != nil {
+					klog.ErrorS(err, "Invalid policy, keeping the previous one", "policy", rs.PolicyName)
+					policyValidationErrors = []string{err.Error()}
+				} else {
+					klog.V(1).InfoS("Applying updated policy", "policy", rs.PolicyName)
+					cycle = reloaded
+					policyValidationErrors = nil
+				}
+			}
+			status = &api.DeschedulerPolicyStatus{ValidationErrors: policyValidationErrors}
+		}
+
+		cycleReport := report.Cycle{Start: time.Now()}
+		defer func() {
+			if rs.CycleReports != nil {
+				cycleReport.DurationSeconds = time.Since(cycleReport.Start).Seconds()
+				rs.CycleReports.Add(cycleReport)
+			}
+			if rs.Health != nil {
+				rs.Health.CycleCompleted()
+			}
+		}()
+
+		// without an interval, the loop ends after a single cycle, be it skipped or not
+		if rs.DeschedulingInterval.Seconds() == 0 {
+			defer cancel()
+		}
+
+		nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, cycle.nodeSelector)
 		if err != nil {
-			klog.V(1).InfoS("Unable to get ready nodes", "err", err)
-			close(stopChannel)
+			cycleReport.Error = fmt.Sprintf("unable to get ready nodes: %v", err)
+			skipCycle("nodes error", err)
 			return
 		}
+		cycleReport.Nodes = len(nodes)
 
-		if len(nodes) <= 1 {
-			klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
-			close(stopChannel)
+		if len(nodes) < cycle.minClusterSize {
+			// eviction would cause service disruption or degradation
+			err := fmt.Errorf("%d ready nodes, fewer than the minimum cluster size %d", len(nodes), cycle.minClusterSize)
+			cycleReport.Error = err.Error()
+			skipCycle("too few nodes", err)
 			return
 		}
+		backoff.reset()
 
 		podEvictor := evictions.NewPodEvictor(
 			rs.Client,
 			evictionPolicyGroupVersion,
 			rs.DryRun,
-			maxNoOfPodsToEvictPerNode,
+			cycle.maxNoOfPodsToEvictPerNode,
+			cycle.maxNoOfPodsToEvictPerNamespace,
+			cycle.maxNoOfPodsToEvictTotal,
 			nodes,
-			evictLocalStoragePods,
-			evictSystemCriticalPods,
-			ignorePvcPods,
+			cycle.evictLocalStoragePods,
+			cycle.evictSystemCriticalPods,
+			cycle.ignorePvcPods,
+			cycle.evictorOpts...,
 		)
 
-		for name, strategy := range deschedulerPolicy.Strategies {
-			if f, ok := strategyFuncs[name]; ok {
+		for _, name := range cycle.strategyNames {
+			if ctx.Err() != nil {
+				klog.V(1).InfoS("Shutting down, skipping the remaining strategies")
+				break
+			}
+			strategy := cycle.policy.Strategies[name]
+			if registered, ok := registry.Lookup(name); ok {
 				if strategy.Enabled {
-					f(ctx, rs.Client, strategy, nodes, podEvictor)
+					podEvictor.SetStrategyOptions(evictions.StrategyOptions{
+						MaxPodsToEvictPerNode:      cycle.strategyMaxPodsToEvictPerNode[name],
+						MaxPodsToEvictTotal:        cycle.strategyMaxPodsToEvictTotal[name],
+						EvictionGracePeriodSeconds: strategyEvictionGracePeriodSeconds(strategy),
+					})
+					registered.New()(ctx, rs.Client, strategy, nodes, podEvictor, podInformer)
+					podEvictor.RetryEvictions(ctx)
+					stats := podEvictor.StrategyStats()
+					cycleReport.Strategies = append(cycleReport.Strategies, report.Strategy{
+						Name:       string(name),
+						Candidates: stats.Candidates,
+						Evicted:    stats.Evicted,
+						Skipped:    stats.Skipped,
+						Errors:     stats.Errors,
+					})
+					if status != nil {
+						status.Strategies = append(status.Strategies, api.StrategyStatus{Name: name, Evicted: podEvictor.StrategyEvicted()})
+					}
 				}
 			} else {
 				klog.ErrorS(fmt.Errorf("unknown strategy name"), "skipping strategy", "strategy", name)
@@ -172,12 +337,189 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 		}
 
 		klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
+		cycleReport.TotalEvicted = podEvictor.TotalEvicted()
 
-		// If there was no interval specified, send a signal to the stopChannel to end the wait.Until loop after 1 iteration
-		if rs.DeschedulingInterval.Seconds() == 0 {
-			close(stopChannel)
+		if status != nil {
+			now := metav1.Now()
+			status.LastRunTime = &now
+			status.TotalEvicted = podEvictor.TotalEvicted()
+			if err := updatePolicyStatus(ctx, rs.DynamicClient, rs.PolicyName, status); err != nil {
+				klog.ErrorS(err, "Unable to report policy status")
+			}
 		}
-	}, rs.DeschedulingInterval, stopChannel)
+	}, backoff, true, ctx.Done())
 
 	return nil
 }
+
+// cycleConfig holds the settings of the descheduling cycles derived from a policy
+type cycleConfig struct {
+	policy                         *api.DeschedulerPolicy
+	nodeSelector                   string
+	evictLocalStoragePods          bool
+	evictSystemCriticalPods        bool
+	ignorePvcPods                  bool
+	maxNoOfPodsToEvictPerNode      int
+	maxNoOfPodsToEvictPerNamespace int
+	maxNoOfPodsToEvictTotal        int
+	minClusterSize                 int
+	evictorOpts                    []evictions.EvictorOption
+	strategyNames                  []api.StrategyName
+	strategyMaxPodsToEvictPerNode  map[api.StrategyName]int
+	strategyMaxPodsToEvictTotal    map[api.StrategyName]int
+}
+
+// newCycleConfig validates the policy and derives the settings of the descheduling cycles from it.
+// The evictor options are extended with the ones set by the policy.
+func newCycleConfig(rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictorOpts []evictions.EvictorOption) (*cycleConfig, error) {
+	if err := ValidatePolicy(deschedulerPolicy); err != nil {
+		return nil, err
+	}
+
+	cycle := &cycleConfig{
+		policy:                    deschedulerPolicy,
+		nodeSelector:              rs.NodeSelector,
+		evictLocalStoragePods:     rs.EvictLocalStoragePods,
+		maxNoOfPodsToEvictPerNode: rs.MaxNoOfPodsToEvictPerNode,
+		evictorOpts:               append([]evictions.EvictorOption{}, evictorOpts...),
+	}
+
+	if deschedulerPolicy.NodeSelector != nil {
+		cycle.nodeSelector = *deschedulerPolicy.NodeSelector
+	}
+
+	if deschedulerPolicy.EvictLocalStoragePods != nil {
+		cycle.evictLocalStoragePods = *deschedulerPolicy.EvictLocalStoragePods
+	}
+
+	if deschedulerPolicy.EvictSystemCriticalPods != nil {
+		cycle.evictSystemCriticalPods = *deschedulerPolicy.EvictSystemCriticalPods
+		if cycle.evictSystemCriticalPods {
+			klog.V(1).InfoS("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
+		}
+	}
+
+	if deschedulerPolicy.IgnorePVCPods != nil {
+		cycle.ignorePvcPods = *deschedulerPolicy.IgnorePVCPods
+	}
+
+	if deschedulerPolicy.MaxNoOfPodsToEvictPerNode != nil {
+		cycle.maxNoOfPodsToEvictPerNode = *deschedulerPolicy.MaxNoOfPodsToEvictPerNode
+	}
+
+	if deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace != nil {
+		cycle.maxNoOfPodsToEvictPerNamespace = *deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace
+	}
+
+	if deschedulerPolicy.MaxNoOfPodsToEvictTotal != nil {
+		cycle.maxNoOfPodsToEvictTotal = *deschedulerPolicy.MaxNoOfPodsToEvictTotal
+	}
+
+	cycle.minClusterSize = defaultMinClusterSize
+	if deschedulerPolicy.MinClusterSize != nil {
+		cycle.minClusterSize = *deschedulerPolicy.MinClusterSize
+	}
+
+	if deschedulerPolicy.EvictionGracePeriodSeconds != nil {
+		cycle.evictorOpts = append(cycle.evictorOpts, evictions.WithEvictionGracePeriodSeconds(deschedulerPolicy.EvictionGracePeriodSeconds))
+	}
+	if deschedulerPolicy.PropagationPolicy != nil {
+		cycle.evictorOpts = append(cycle.evictorOpts, evictions.WithPropagationPolicy(deschedulerPolicy.PropagationPolicy))
+	}
+	if deschedulerPolicy.EvictionQPS != nil && *deschedulerPolicy.EvictionQPS > 0 {
+		evictionBurst := 1
+		if deschedulerPolicy.EvictionBurst != nil {
+			evictionBurst = *deschedulerPolicy.EvictionBurst
+		}
+		// the rate limiter is shared by all the descheduling cycles run with the policy
+		cycle.evictorOpts = append(cycle.evictorOpts, evictions.WithRateLimiter(flowcontrol.NewTokenBucketRateLimiter(*deschedulerPolicy.EvictionQPS, evictionBurst)))
+	}
+	if deschedulerPolicy.MaxEvictionRetries != nil {
+		cycle.evictorOpts = append(cycle.evictorOpts, evictions.WithEvictionRetries(*deschedulerPolicy.MaxEvictionRetries, defaultEvictionRetryBackoff))
+	}
+	if deschedulerPolicy.NodeConcurrency != nil {
+		cycle.evictorOpts = append(cycle.evictorOpts, evictions.WithNodeConcurrency(*deschedulerPolicy.NodeConcurrency))
+	}
+
+	cycle.strategyNames = sortStrategiesByWeight(deschedulerPolicy.Strategies)
+	warnMetricsWindows(deschedulerPolicy, cycle.strategyNames, rs.DeschedulingInterval)
+	cycle.strategyMaxPodsToEvictPerNode = splitEvictionLimitByWeight(deschedulerPolicy.Strategies, cycle.maxNoOfPodsToEvictPerNode)
+	cycle.strategyMaxPodsToEvictTotal = splitEvictionLimitByWeight(deschedulerPolicy.Strategies, cycle.maxNoOfPodsToEvictTotal)
+
+	return cycle, nil
+}
+
+// strategyEvictionGracePeriodSeconds returns the grace period the strategy overrides the policy one with, if any
+func strategyEvictionGracePeriodSeconds(strategy api.DeschedulerStrategy) *int64 {
+	if strategy.Params == nil {
+		return nil
+	}
+	return strategy.Params.EvictionGracePeriodSeconds
+}
+
+// warnMetricsWindows warns about the metrics windows of the enabled strategies holding a single usage sample,
+// the usage being read once per cycle. The validation of the policy does not know the descheduling interval.
+func warnMetricsWindows(deschedulerPolicy *api.DeschedulerPolicy, names []api.StrategyName, deschedulingInterval time.Duration) {
+	for _, name := range names {
+		strategy := deschedulerPolicy.Strategies[name]
+		if !strategy.Enabled || strategy.Params == nil || strategy.Params.NodeResourceUtilizationThresholds == nil {
+			continue
+		}
+		window := strategy.Params.NodeResourceUtilizationThresholds.MetricsWindowSeconds
+		if window == nil || *window == 0 {
+			continue
+		}
+		if deschedulingInterval == 0 || time.Duration(*window)*time.Second <= deschedulingInterval {
+			klog.V(1).InfoS("Warning: the metrics window does not span several descheduling intervals, the usage is not smoothed", "strategy", name, "metricsWindowSeconds", *window, "deschedulingInterval", deschedulingInterval)
+		}
+	}
+}
+
+// sortStrategiesByWeight orders the strategies by their weight, the heaviest first.
+// Strategies of the same weight are ordered by name so they run in the same order in every cycle.
+func sortStrategiesByWeight(strategyList api.StrategyList) []api.StrategyName {
+	names := make([]api.StrategyName, 0, len(strategyList))
+	for name := range strategyList {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		wi, wj := strategyList[names[i]].Weight, strategyList[names[j]].Weight
+		if wi != wj {
+			return wi > wj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// splitEvictionLimitByWeight splits an eviction limit between the enabled strategies
+// proportionally to their weight. Every weighted strategy is allowed to evict at least one pod.
+// Strategies without a weight are restricted only by the overall limit, so they get what is left by the others.
+func splitEvictionLimitByWeight(strategyList api.StrategyList, maxPodsToEvict int) map[api.StrategyName]int {
+	limits := map[api.StrategyName]int{}
+	if maxPodsToEvict <= 0 {
+		return limits
+	}
+
+	totalWeight := 0
+	for _, strategy := range strategyList {
+		if strategy.Enabled && strategy.Weight > 0 {
+			totalWeight += strategy.Weight
+		}
+	}
+	if totalWeight == 0 {
+		return limits
+	}
+
+	for name, strategy := range strategyList {
+		if !strategy.Enabled || strategy.Weight <= 0 {
+			continue
+		}
+		limit := maxPodsToEvict * strategy.Weight / totalWeight
+		if limit < 1 {
+			limit = 1
+		}
+		limits[name] = limit
+	}
+	return limits
+}
@@ -3,6 +3,7 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -10,9 +11,15 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/health"
+	"sigs.k8s.io/descheduler/pkg/descheduler/report"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
 	"sigs.k8s.io/descheduler/test"
 )
 
@@ -45,7 +52,6 @@ func TestTaintsUpdated(t *testing.T) {
 	rs.Client = client
 	rs.DeschedulingInterval = 100 * time.Millisecond
 	errChan := make(chan error, 1)
-	defer close(errChan)
 	go func() {
 		err := RunDeschedulerStrategies(ctx, rs, dp, "v1beta1", stopChannel)
 		errChan <- err
@@ -101,3 +107,308 @@ func TestTaintsUpdated(t *testing.T) {
 		t.Fatalf("Unable to evict pod, node taint did not get propagated to descheduler strategies")
 	}
 }
+
+func TestRunDeschedulerStrategiesStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	rs.DeschedulingInterval = 100 * time.Millisecond
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- RunDeschedulerStrategies(ctx, rs, &api.DeschedulerPolicy{}, "v1beta1", stopChannel)
+	}()
+
+	// Cancelling the context, e.g. after losing the leader election lease, must end the loop
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("Unable to run descheduler strategies: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Descheduler strategies did not stop after the context was cancelled")
+	}
+}
+
+func TestCycleReports(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 200, 0, n1.Name, test.SetRSOwnerRef)
+	p2 := test.BuildTestPod("p2", 200, 0, n1.Name, test.SetRSOwnerRef)
+	p2.Spec.Volumes = []v1.Volume{{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2, p1, p2)
+	rs.DryRun = true
+	rs.CycleReports = report.NewStore(rs.MaxCycleReports)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true},
+		},
+	}
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1", stopChannel); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	cycles := rs.CycleReports.List()
+	if len(cycles) != 1 {
+		t.Fatalf("Expected a single cycle report, got %+v", cycles)
+	}
+	cycle := cycles[0]
+	if cycle.Nodes != 2 || cycle.TotalEvicted != 1 || cycle.Error != "" || cycle.Start.IsZero() {
+		t.Errorf("Unexpected cycle report %+v", cycle)
+	}
+	expected := []report.Strategy{{
+		Name:       "RemovePodsViolatingNodeTaints",
+		Candidates: 1,
+		Evicted:    1,
+		Skipped:    map[string]int{"LocalStorage": 1},
+	}}
+	if !reflect.DeepEqual(cycle.Strategies, expected) {
+		t.Errorf("Expected strategy reports %+v, got %+v", expected, cycle.Strategies)
+	}
+}
+
+func TestMinClusterSize(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "key", Value: "value", Effect: v1.TaintEffectNoSchedule}}
+	})
+	p1 := test.BuildTestPod("p1", 200, 0, n1.Name, test.SetRSOwnerRef)
+	one := 1
+
+	tests := []struct {
+		description    string
+		minClusterSize *int
+		expectedError  string
+		expectedEvicts int
+	}{
+		{
+			description:   "single node cluster skipped by default",
+			expectedError: "1 ready nodes, fewer than the minimum cluster size 2",
+		},
+		{
+			description:    "single node cluster allowed by the policy",
+			minClusterSize: &one,
+			expectedEvicts: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			rs, err := options.NewDeschedulerServer()
+			if err != nil {
+				t.Fatalf("Unable to initialize server: %v", err)
+			}
+			rs.Client = fakeclientset.NewSimpleClientset(n1, p1)
+			rs.DryRun = true
+			rs.CycleReports = report.NewStore(rs.MaxCycleReports)
+			dp := &api.DeschedulerPolicy{
+				MinClusterSize: tc.minClusterSize,
+				Strategies: api.StrategyList{
+					"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true},
+				},
+			}
+
+			stopChannel := make(chan struct{})
+			defer close(stopChannel)
+			if err := RunDeschedulerStrategies(ctx, rs, dp, "v1", stopChannel); err != nil {
+				t.Fatalf("Unable to run descheduler strategies: %v", err)
+			}
+
+			cycles := rs.CycleReports.List()
+			if len(cycles) != 1 {
+				t.Fatalf("Expected a single cycle report, got %+v", cycles)
+			}
+			if cycles[0].Error != tc.expectedError || cycles[0].TotalEvicted != tc.expectedEvicts {
+				t.Errorf("Expected error %q and %d evictions, got %+v", tc.expectedError, tc.expectedEvicts, cycles[0])
+			}
+		})
+	}
+}
+
+func TestRegisteredStrategy(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	var ranWith []string
+	registry.Register(registry.Strategy{
+		Name: "TestRegisteredStrategy",
+		New: func() registry.StrategyFunction {
+			return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+				ranWith = strategy.Params.Namespaces.Include
+			}
+		},
+		DefaultParams: func() *api.StrategyParameters {
+			return &api.StrategyParameters{Namespaces: &api.Namespaces{Include: []string{"default"}}}
+		},
+	})
+
+	dp, err := decodePolicy("policy.yaml", []byte(`
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+strategies:
+  "TestRegisteredStrategy":
+     enabled: true
+`))
+	if err != nil {
+		t.Fatalf("Unable to decode policy: %v", err)
+	}
+	if err := ValidatePolicy(dp); err != nil {
+		t.Fatalf("Expected the registered strategy to be valid, got %v", err)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1", stopChannel); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+	if expected := []string{"default"}; !reflect.DeepEqual(ranWith, expected) {
+		t.Errorf("Expected the registered strategy to run with its default namespaces %v, got %v", expected, ranWith)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2)
+	rs.Health = health.NewState(time.Nanosecond)
+	rs.Health.SetEvictionSupported()
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true},
+		},
+	}
+
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	if err := RunDeschedulerStrategies(ctx, rs, dp, "v1", stopChannel); err != nil {
+		t.Fatalf("Unable to run descheduler strategies: %v", err)
+	}
+
+	if err := rs.Health.Ready(); err != nil {
+		t.Errorf("Expected to be ready once the informers synced, got %v", err)
+	}
+	if err := rs.Health.Alive(); err != nil {
+		t.Errorf("Expected to be alive once the descheduling loop returned, got %v", err)
+	}
+}
+
+func TestSortStrategiesByWeight(t *testing.T) {
+	strategyList := api.StrategyList{
+		"LowNodeUtilization":              api.DeschedulerStrategy{Enabled: true},
+		"RemoveDuplicates":                api.DeschedulerStrategy{Enabled: true, Weight: 1},
+		"RemovePodsViolatingNodeTaints":   api.DeschedulerStrategy{Enabled: true, Weight: 10},
+		"RemovePodsViolatingNodeAffinity": api.DeschedulerStrategy{Enabled: true, Weight: 10},
+		"PodLifeTime":                     api.DeschedulerStrategy{Enabled: false},
+	}
+	expected := []api.StrategyName{
+		"RemovePodsViolatingNodeAffinity",
+		"RemovePodsViolatingNodeTaints",
+		"RemoveDuplicates",
+		"LowNodeUtilization",
+		"PodLifeTime",
+	}
+
+	for i := 0; i < 10; i++ {
+		if got := sortStrategiesByWeight(strategyList); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("Expected strategies to be ordered as %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestSplitEvictionLimitByWeight(t *testing.T) {
+	tests := []struct {
+		description    string
+		strategyList   api.StrategyList
+		maxPodsToEvict int
+		expected       map[api.StrategyName]int
+	}{
+		{
+			description: "no overall limit",
+			strategyList: api.StrategyList{
+				"RemoveDuplicates": api.DeschedulerStrategy{Enabled: true, Weight: 1},
+			},
+			maxPodsToEvict: 0,
+			expected:       map[api.StrategyName]int{},
+		},
+		{
+			description: "no weights",
+			strategyList: api.StrategyList{
+				"RemoveDuplicates":   api.DeschedulerStrategy{Enabled: true},
+				"LowNodeUtilization": api.DeschedulerStrategy{Enabled: true},
+			},
+			maxPodsToEvict: 10,
+			expected:       map[api.StrategyName]int{},
+		},
+		{
+			description: "limit split proportionally to the weights",
+			strategyList: api.StrategyList{
+				"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true, Weight: 3},
+				"RemoveDuplicates":              api.DeschedulerStrategy{Enabled: true, Weight: 1},
+				"PodLifeTime":                   api.DeschedulerStrategy{Enabled: false, Weight: 4},
+				"LowNodeUtilization":            api.DeschedulerStrategy{Enabled: true},
+			},
+			maxPodsToEvict: 10,
+			expected: map[api.StrategyName]int{
+				"RemovePodsViolatingNodeTaints": 7,
+				"RemoveDuplicates":              2,
+			},
+		},
+		{
+			description: "every weighted strategy evicts at least one pod",
+			strategyList: api.StrategyList{
+				"RemovePodsViolatingNodeTaints": api.DeschedulerStrategy{Enabled: true, Weight: 10},
+				"RemoveDuplicates":              api.DeschedulerStrategy{Enabled: true, Weight: 1},
+			},
+			maxPodsToEvict: 2,
+			expected: map[api.StrategyName]int{
+				"RemovePodsViolatingNodeTaints": 1,
+				"RemoveDuplicates":              1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got := splitEvictionLimitByWeight(test.strategyList, test.maxPodsToEvict)
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("Expected limits %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
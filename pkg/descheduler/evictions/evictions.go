@@ -20,19 +20,26 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
-	policy "k8s.io/api/policy/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -42,21 +49,208 @@ import (
 
 const (
 	evictPodAnnotationKey = "descheduler.alpha.kubernetes.io/evict"
+
+	// EventReasonDescheduled is the reason of the events recorded for the evicted pods
+	EventReasonDescheduled = "Descheduled"
+	// EventReasonDescheduledDryRun is the reason of the events recorded for the pods evicted in dry run mode
+	EventReasonDescheduledDryRun = "DescheduledDryRun"
+	// EventReasonDescheduleSkipped is the reason of the events recorded for the evictions skipped because of a limit
+	EventReasonDescheduleSkipped = "DescheduleSkipped"
+	// EventReasonDescheduleFailed is the reason of the events recorded for the failed evictions
+	EventReasonDescheduleFailed = "DescheduleFailed"
+
+	// maxEvictionRetryQueueSize bounds the number of evictions waiting to be retried
+	maxEvictionRetryQueueSize = 100
+	// maxEvictionRetryBackoff caps the exponential backoff between the retries of an eviction
+	maxEvictionRetryBackoff = 5 * time.Minute
 )
 
 // nodePodEvictedCount keeps count of pods evicted on node
 type nodePodEvictedCount map[*v1.Node]int
 
+// StrategyOptions restricts the evictions of a single strategy run
+type StrategyOptions struct {
+	// MaxPodsToEvictPerNode restricts maximum of pods the strategy can evict per node, 0 means no restriction.
+	// The limit of the PodEvictor still applies on top of it.
+	MaxPodsToEvictPerNode int
+	// MaxPodsToEvictTotal restricts maximum of pods the strategy can evict in total, 0 means no restriction.
+	// The limit of the PodEvictor still applies on top of it.
+	MaxPodsToEvictTotal int
+	// EvictionGracePeriodSeconds overrides the grace period the PodEvictor evicts pods with.
+	EvictionGracePeriodSeconds *int64
+}
+
+// PodEvictor evicts pods within the limits of the policy and of the strategy being run.
+// It is safe for concurrent use by the goroutines of a strategy.
 type PodEvictor struct {
 	client                  clientset.Interface
 	nodes                   []*v1.Node
 	policyGroupVersion      string
 	dryRun                  bool
 	maxPodsToEvictPerNode   int
+	maxPodsToEvictPerNs     int
+	maxPodsToEvictTotal     int
+	nodeConcurrency         int
 	nodepodCount            nodePodEvictedCount
+	namespacePodCount       map[string]int
 	evictLocalStoragePods   bool
 	evictSystemCriticalPods bool
 	ignorePvcPods           bool
+	strategyOptions         StrategyOptions
+	strategyNodepodCount    nodePodEvictedCount
+	strategyPodCount        int
+	gracePeriodSeconds      *int64
+	propagationPolicy       *metav1.DeletionPropagation
+	rateLimiter             flowcontrol.RateLimiter
+	maxEvictionRetries      int
+	evictionRetryBackoff    time.Duration
+	retryQueue              []evictionRetry
+	pdbLister               policyv1listers.PodDisruptionBudgetLister
+	eventRecorder           record.EventRecorder
+	auditSink               audit.Sink
+	metricsClient           metricsclientset.Interface
+	// mu guards the counts, the stats, the evicted pods and the retry queue
+	mu sync.Mutex
+	// evictedPods keeps the pods evicted or being evicted per namespace, to account for the
+	// disruptions they used up before the PodDisruptionBudgets are updated
+	evictedPods map[string][]*v1.Pod
+	// strategyStats accounts for the pods considered by the strategy being run
+	strategyStats strategyStats
+}
+
+// maxStrategyErrors caps the number of eviction errors kept for a strategy run
+const maxStrategyErrors = 20
+
+// StrategyStats tells what happened to the pods considered by a strategy run
+type StrategyStats struct {
+	// Candidates is the number of pods the strategy tried to evict or delete
+	Candidates int
+	// Evicted is the number of pods evicted or deleted
+	Evicted int
+	// Skipped counts the pods not evicted by reason, either the limit which prevented the eviction
+	// or the first Evictable constraint the pod failed
+	Skipped map[string]int
+	// Errors are the errors of the failed evictions, up to 20
+	Errors []string
+}
+
+type strategyStats struct {
+	candidates int
+	evicted    int
+	// skipped keeps the pods skipped by reason, a pod being checked by Evictable more than once
+	skipped map[string]sets.String
+	errors  []string
+}
+
+func (s *strategyStats) skip(reason string, pod *v1.Pod) {
+	if s.skipped == nil {
+		s.skipped = map[string]sets.String{}
+	}
+	if s.skipped[reason] == nil {
+		s.skipped[reason] = sets.NewString()
+	}
+	s.skipped[reason].Insert(klog.KObj(pod).String())
+}
+
+func (s *strategyStats) fail(pod *v1.Pod, err error) {
+	if len(s.errors) < maxStrategyErrors {
+		s.errors = append(s.errors, fmt.Sprintf("%s: %v", klog.KObj(pod), err))
+	}
+}
+
+// evictionRetry is an eviction rejected with 429 Too Many Requests waiting to be retried
+type evictionRetry struct {
+	pod       *v1.Pod
+	node      *v1.Node
+	strategy  string
+	reason    string
+	attempts  int
+	notBefore time.Time
+}
+
+// EvictorOption configures optional behaviour of the PodEvictor
+type EvictorOption func(pe *PodEvictor)
+
+// WithEvictionGracePeriodSeconds sets the termination grace period of the evicted pods.
+// The grace period of the pod is used when not set.
+func WithEvictionGracePeriodSeconds(gracePeriodSeconds *int64) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.gracePeriodSeconds = gracePeriodSeconds
+	}
+}
+
+// WithRateLimiter limits the rate at which pods are evicted.
+func WithRateLimiter(rateLimiter flowcontrol.RateLimiter) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.rateLimiter = rateLimiter
+	}
+}
+
+// WithEvictionRetries retries the evictions rejected with 429 Too Many Requests, usually
+// because of a PodDisruptionBudget, up to maxRetries times. The delay between the retries
+// starts at backoff and doubles with every attempt.
+func WithEvictionRetries(maxRetries int, backoff time.Duration) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.maxEvictionRetries = maxRetries
+		pe.evictionRetryBackoff = backoff
+	}
+}
+
+// WithEventRecorder records events for the evicted, skipped and failed evictions with eventRecorder.
+// The recorder is expected to be shared by all the PodEvictors for the lifetime of the descheduler.
+func WithEventRecorder(eventRecorder record.EventRecorder) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.eventRecorder = eventRecorder
+	}
+}
+
+// nopEventRecorder drops the events of the PodEvictors created without WithEventRecorder
+type nopEventRecorder struct{}
+
+func (nopEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {}
+
+func (nopEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+}
+
+func (nopEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+}
+
+// WithAuditSink writes an audit record for every pod the PodEvictor evicts or tries to evict to auditSink.
+func WithAuditSink(auditSink audit.Sink) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.auditSink = auditSink
+	}
+}
+
+// WithPodDisruptionBudgetLister lets the PodEvictor consult the PodDisruptionBudgets
+// of the pods, see WithPodDisruptionBudgets. The PodDisruptionBudgets are checked again when
+// the eviction is requested, so that the pods of nodes evaluated in parallel do not use up
+// the same disruption.
+func WithPodDisruptionBudgetLister(pdbLister policyv1listers.PodDisruptionBudgetLister) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.pdbLister = pdbLister
+	}
+}
+
+// WithNodeConcurrency sets how many nodes the strategies evaluating the nodes one at a time evaluate in parallel
+func WithNodeConcurrency(nodeConcurrency int) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.nodeConcurrency = nodeConcurrency
+	}
+}
+
+// WithMetricsClient lets the strategies read the resource usage of the nodes and pods from the metrics.k8s.io API
+func WithMetricsClient(metricsClient metricsclientset.Interface) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.metricsClient = metricsClient
+	}
+}
+
+// WithPropagationPolicy sets whether and how garbage collection is performed when pods are evicted.
+func WithPropagationPolicy(propagationPolicy *metav1.DeletionPropagation) EvictorOption {
+	return func(pe *PodEvictor) {
+		pe.propagationPolicy = propagationPolicy
+	}
 }
 
 func NewPodEvictor(
@@ -64,10 +258,13 @@ func NewPodEvictor(
 	policyGroupVersion string,
 	dryRun bool,
 	maxPodsToEvictPerNode int,
+	maxPodsToEvictPerNamespace int,
+	maxPodsToEvictTotal int,
 	nodes []*v1.Node,
 	evictLocalStoragePods bool,
 	evictSystemCriticalPods bool,
 	ignorePvcPods bool,
+	opts ...EvictorOption,
 ) *PodEvictor {
 	var nodePodCount = make(nodePodEvictedCount)
 	for _, node := range nodes {
@@ -75,26 +272,125 @@ func NewPodEvictor(
 		nodePodCount[node] = 0
 	}
 
-	return &PodEvictor{
+	pe := &PodEvictor{
 		client:                  client,
 		nodes:                   nodes,
 		policyGroupVersion:      policyGroupVersion,
 		dryRun:                  dryRun,
 		maxPodsToEvictPerNode:   maxPodsToEvictPerNode,
+		maxPodsToEvictPerNs:     maxPodsToEvictPerNamespace,
+		maxPodsToEvictTotal:     maxPodsToEvictTotal,
 		nodepodCount:            nodePodCount,
+		namespacePodCount:       make(map[string]int),
 		evictLocalStoragePods:   evictLocalStoragePods,
 		evictSystemCriticalPods: evictSystemCriticalPods,
 		ignorePvcPods:           ignorePvcPods,
+		strategyNodepodCount:    make(nodePodEvictedCount),
+		evictedPods:             make(map[string][]*v1.Pod),
+		// no events are recorded unless a recorder is set with WithEventRecorder
+		eventRecorder: nopEventRecorder{},
+	}
+	for _, opt := range opts {
+		opt(pe)
+	}
+	return pe
+}
+
+// SetStrategyOptions sets the restrictions of the strategy about to run
+// and resets the number of pods evicted by the previous strategy.
+func (pe *PodEvictor) SetStrategyOptions(opts StrategyOptions) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.strategyOptions = opts
+	pe.strategyNodepodCount = make(nodePodEvictedCount)
+	pe.strategyPodCount = 0
+	pe.strategyStats = strategyStats{}
+}
+
+// StrategyStats gives what happened to the pods considered by the strategy being run
+func (pe *PodEvictor) StrategyStats() StrategyStats {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	stats := StrategyStats{
+		Candidates: pe.strategyStats.candidates,
+		Evicted:    pe.strategyStats.evicted,
+		Skipped:    map[string]int{},
+		Errors:     append([]string(nil), pe.strategyStats.errors...),
+	}
+	for reason, pods := range pe.strategyStats.skipped {
+		stats.Skipped[reason] = pods.Len()
+	}
+	return stats
+}
+
+// CountDeletion accounts for a pod the strategy being run deleted rather than evicted, as the
+// defragmentation strategies do, in the StrategyStats. err is the error of the deletion, if any.
+func (pe *PodEvictor) CountDeletion(pod *v1.Pod, err error) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.strategyStats.candidates++
+	if err != nil {
+		pe.strategyStats.fail(pod, err)
+		return
 	}
+	pe.strategyStats.evicted++
 }
 
 // NodeEvicted gives a number of pods evicted for node
 func (pe *PodEvictor) NodeEvicted(node *v1.Node) int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
 	return pe.nodepodCount[node]
 }
 
+// DeleteOptions gives the options pods are evicted or deleted with by the strategy being run
+func (pe *PodEvictor) DeleteOptions() *metav1.DeleteOptions {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.deleteOptions()
+}
+
+func (pe *PodEvictor) deleteOptions() *metav1.DeleteOptions {
+	gracePeriodSeconds := pe.gracePeriodSeconds
+	if pe.strategyOptions.EvictionGracePeriodSeconds != nil {
+		gracePeriodSeconds = pe.strategyOptions.EvictionGracePeriodSeconds
+	}
+	return &metav1.DeleteOptions{
+		GracePeriodSeconds: gracePeriodSeconds,
+		PropagationPolicy:  pe.propagationPolicy,
+	}
+}
+
+// NamespaceEvicted gives a number of pods evicted in namespace
+func (pe *PodEvictor) NamespaceEvicted(namespace string) int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.namespacePodCount[namespace]
+}
+
+// StrategyEvicted gives a number of pods evicted by the strategy being run
+func (pe *PodEvictor) StrategyEvicted() int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.strategyPodCount
+}
+
 // TotalEvicted gives a number of pods evicted through all nodes
 func (pe *PodEvictor) TotalEvicted() int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.totalEvicted()
+}
+
+// NodeConcurrency gives how many nodes the strategies evaluating the nodes one at a time evaluate in parallel
+func (pe *PodEvictor) NodeConcurrency() int {
+	if pe.nodeConcurrency < 1 {
+		return 1
+	}
+	return pe.nodeConcurrency
+}
+
+func (pe *PodEvictor) totalEvicted() int {
 	var total int
 	for _, count := range pe.nodepodCount {
 		total += count
@@ -103,62 +399,293 @@ func (pe *PodEvictor) TotalEvicted() int {
 }
 
 // EvictPod returns non-nil error only when evicting a pod on a node is not
-// possible (due to maxPodsToEvictPerNode constraint). Success is true when the pod
-// is evicted on the server side.
+// possible (due to maxPodsToEvictPerNode, maxPodsToEvictPerNamespace or maxPodsToEvictTotal
+// constraint). Success is true when the pod is evicted on the server side.
 func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, node *v1.Node, strategy string, reasons ...string) (bool, error) {
 	reason := strategy
 	if len(reasons) > 0 {
 		reason += " (" + strings.Join(reasons, ", ") + ")"
 	}
-	if pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode {
-		metrics.PodsEvicted.With(map[string]string{"result": "maximum number reached", "strategy": strategy, "namespace": pod.Namespace}).Inc()
-		return false, fmt.Errorf("Maximum number %v of evicted pods per %q node reached", pe.maxPodsToEvictPerNode, node.Name)
+	return pe.evictPod(ctx, pod, node, strategy, reason, 0)
+}
+
+// RetryEvictions retries the evictions rejected with 429 Too Many Requests with an exponential
+// backoff until they succeed, run out of attempts or the context is done.
+func (pe *PodEvictor) RetryEvictions(ctx context.Context) {
+	for {
+		retry, ok := pe.nextEvictionRetry()
+		if !ok {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			pe.mu.Lock()
+			klog.V(1).InfoS("Dropping evictions waiting to be retried", "count", len(pe.retryQueue)+1)
+			pe.retryQueue = nil
+			pe.mu.Unlock()
+			return
+		case <-time.After(time.Until(retry.notBefore)):
+		}
+
+		if _, err := pe.evictPod(ctx, retry.pod, retry.node, retry.strategy, retry.reason, retry.attempts); err != nil {
+			klog.V(1).InfoS("Unable to retry eviction", "pod", klog.KObj(retry.pod), "err", err)
+		}
+	}
+}
+
+func (pe *PodEvictor) evictPod(ctx context.Context, pod *v1.Pod, node *v1.Node, strategy, reason string, attempts int) (bool, error) {
+	var pdbs []*policyv1.PodDisruptionBudget
+	var pdbErr error
+	if pe.pdbLister != nil {
+		pdbs, pdbErr = pe.pdbLister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
 	}
 
-	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion, pe.dryRun)
+	pe.mu.Lock()
+	// the retries were accounted for with the first attempt
+	if attempts == 0 {
+		pe.strategyStats.candidates++
+	}
+	if result, err := pe.checkLimits(pod, node, strategy); err != nil {
+		pe.strategyStats.skip(result, pod)
+		pe.mu.Unlock()
+		return pe.skipEviction(pod, node, strategy, reason, result, err)
+	}
+	if pdbErr == nil {
+		pdbErr = pe.checkDisruptionsAllowed(pod, pdbs)
+	}
+	if pdbErr != nil {
+		// another eviction used up the disruption since the pod was found evictable
+		pe.strategyStats.skip("PodDisruptionBudget", pod)
+		pe.mu.Unlock()
+		klog.V(4).InfoS("Pod is not evictable", "pod", klog.KObj(pod), "err", pdbErr)
+		return false, nil
+	}
+	// the eviction is counted, and the disruption reserved, before it is requested, so that
+	// concurrent evictions do not exceed the limits nor the PodDisruptionBudgets
+	pe.countEviction(pod, node, 1)
+	pe.evictedPods[pod.Namespace] = append(pe.evictedPods[pod.Namespace], pod)
+	deleteOptions := pe.deleteOptions()
+	pe.mu.Unlock()
+
+	var err error
+	if pe.rateLimiter != nil && !pe.dryRun {
+		err = pe.rateLimiter.Wait(ctx)
+	}
+	if err == nil {
+		err = evictPod(ctx, pe.client, pod, pe.policyGroupVersion, deleteOptions, pe.dryRun)
+	}
 	if err != nil {
+		pe.mu.Lock()
+		pe.countEviction(pod, node, -1)
+		pe.releaseDisruption(pod)
+		retried := apierrors.IsTooManyRequests(err) && pe.queueEvictionRetry(pod, node, strategy, reason, attempts)
+		if !retried {
+			pe.strategyStats.fail(pod, err)
+		}
+		pe.mu.Unlock()
+		if retried {
+			klog.V(1).InfoS("Eviction rejected, retrying later", "pod", klog.KObj(pod), "reason", reason, "attempt", attempts+1, "err", err)
+			return false, nil
+		}
 		// err is used only for logging purposes
 		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", reason)
+		pe.eventRecorder.Event(pod, v1.EventTypeWarning, EventReasonDescheduleFailed, fmt.Sprintf("pod eviction by sigs.k8s.io/descheduler %s failed: %v", reason, err))
 		metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": strategy, "namespace": pod.Namespace}).Inc()
+		pe.Audit(pod, node, strategy, reason, "error")
 		return false, nil
 	}
 
-	pe.nodepodCount[node]++
+	pe.mu.Lock()
+	pe.strategyStats.evicted++
+	pe.mu.Unlock()
+	pe.Audit(pod, node, strategy, reason, "success")
 	if pe.dryRun {
 		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", reason)
+		pe.eventRecorder.Event(pod, v1.EventTypeNormal, EventReasonDescheduledDryRun, fmt.Sprintf("pod would have been evicted by sigs.k8s.io/descheduler %s", reason))
 	} else {
 		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", reason)
-		eventBroadcaster := record.NewBroadcaster()
-		eventBroadcaster.StartStructuredLogging(3)
-		eventBroadcaster.StartRecordingToSink(&clientcorev1.EventSinkImpl{Interface: pe.client.CoreV1().Events(pod.Namespace)})
-		r := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "sigs.k8s.io.descheduler"})
-		r.Event(pod, v1.EventTypeNormal, "Descheduled", fmt.Sprintf("pod evicted by sigs.k8s.io/descheduler%s", reason))
+		pe.eventRecorder.Event(pod, v1.EventTypeNormal, EventReasonDescheduled, fmt.Sprintf("pod evicted by sigs.k8s.io/descheduler %s", reason))
 		metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": strategy, "namespace": pod.Namespace}).Inc()
 	}
 	return true, nil
 }
 
-func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, dryRun bool) error {
+// checkLimits errors when evicting the pod would exceed a limit of the PodEvictor or the strategy,
+// giving the result the skipped eviction is recorded with. It is called with mu held.
+func (pe *PodEvictor) checkLimits(pod *v1.Pod, node *v1.Node, strategy string) (string, error) {
+	if pe.maxPodsToEvictPerNode > 0 && pe.nodepodCount[node]+1 > pe.maxPodsToEvictPerNode {
+		return "maximum number reached", fmt.Errorf("Maximum number %v of evicted pods per %q node reached", pe.maxPodsToEvictPerNode, node.Name)
+	}
+	if pe.strategyOptions.MaxPodsToEvictPerNode > 0 && pe.strategyNodepodCount[node]+1 > pe.strategyOptions.MaxPodsToEvictPerNode {
+		return "maximum number reached", fmt.Errorf("Maximum number %v of evicted pods per %q node reached for strategy %v", pe.strategyOptions.MaxPodsToEvictPerNode, node.Name, strategy)
+	}
+	if pe.maxPodsToEvictPerNs > 0 && pe.namespacePodCount[pod.Namespace]+1 > pe.maxPodsToEvictPerNs {
+		return "maximum number per namespace reached", &namespaceLimitError{limit: pe.maxPodsToEvictPerNs, namespace: pod.Namespace}
+	}
+	if pe.maxPodsToEvictTotal > 0 && pe.totalEvicted()+1 > pe.maxPodsToEvictTotal {
+		return "maximum number in total reached", fmt.Errorf("Maximum number %v of evicted pods in total reached", pe.maxPodsToEvictTotal)
+	}
+	if pe.strategyOptions.MaxPodsToEvictTotal > 0 && pe.strategyPodCount+1 > pe.strategyOptions.MaxPodsToEvictTotal {
+		return "maximum number in total reached", fmt.Errorf("Maximum number %v of evicted pods in total reached for strategy %v", pe.strategyOptions.MaxPodsToEvictTotal, strategy)
+	}
+	return "", nil
+}
+
+// namespaceLimitError is the error of an eviction exceeding the maximum number of pods evicted per namespace
+type namespaceLimitError struct {
+	limit     int
+	namespace string
+}
+
+func (e *namespaceLimitError) Error() string {
+	return fmt.Sprintf("Maximum number %v of evicted pods per %q namespace reached", e.limit, e.namespace)
+}
+
+// IsNamespaceLimitReached tells whether EvictPod failed because the maximum number of pods evicted
+// in the namespace of the pod is reached. Unlike the other limits, pods of other namespaces may still
+// be evicted, strategies skip the pod rather than stop evicting.
+func IsNamespaceLimitReached(err error) bool {
+	_, ok := err.(*namespaceLimitError)
+	return ok
+}
+
+// countEviction adds delta to the counts of the pods evicted on the node, in the namespace of the pod
+// and by the strategy. It is called with mu held.
+func (pe *PodEvictor) countEviction(pod *v1.Pod, node *v1.Node, delta int) {
+	pe.nodepodCount[node] += delta
+	pe.strategyNodepodCount[node] += delta
+	pe.namespacePodCount[pod.Namespace] += delta
+	pe.strategyPodCount += delta
+}
+
+// releaseDisruption forgets the disruption reserved for the pod whose eviction failed. It is called with mu held.
+func (pe *PodEvictor) releaseDisruption(pod *v1.Pod) {
+	evictedPods := pe.evictedPods[pod.Namespace]
+	for i := len(evictedPods) - 1; i >= 0; i-- {
+		if evictedPods[i] == pod {
+			pe.evictedPods[pod.Namespace] = append(evictedPods[:i:i], evictedPods[i+1:]...)
+			return
+		}
+	}
+}
+
+// countSkipped accounts for a pod the strategy being run did not evict for the given reason in the StrategyStats
+func (pe *PodEvictor) countSkipped(reason string, pod *v1.Pod) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.strategyStats.skip(reason, pod)
+}
+
+// skipEviction records an eviction skipped because of a limit of the PodEvictor or the strategy
+func (pe *PodEvictor) skipEviction(pod *v1.Pod, node *v1.Node, strategy, reason, result string, err error) (bool, error) {
+	metrics.PodsEvicted.With(map[string]string{"result": result, "strategy": strategy, "namespace": pod.Namespace}).Inc()
+	pe.Audit(pod, node, strategy, reason, result)
+	pe.eventRecorder.Event(pod, v1.EventTypeNormal, EventReasonDescheduleSkipped, fmt.Sprintf("pod eviction by sigs.k8s.io/descheduler %s skipped: %v", strategy, err))
+	return false, err
+}
+
+// Audit writes the audit record of a pod the descheduler evicted, deleted or tried to, if the PodEvictor
+// has an audit sink. The result is "success", "error" or the reason the pod was not evicted.
+func (pe *PodEvictor) Audit(pod *v1.Pod, node *v1.Node, strategy, reason, result string) {
+	if pe.auditSink == nil {
+		return
+	}
+	record := audit.Record{
+		Timestamp: time.Now(),
+		Strategy:  strategy,
+		Reason:    reason,
+		Pod:       klog.KObj(pod).String(),
+		DryRun:    pe.dryRun,
+		Result:    result,
+	}
+	if node != nil {
+		record.Node = node.Name
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		record.Owner = owner.Kind + "/" + owner.Name
+	} else if ownerRefs := pod.GetOwnerReferences(); len(ownerRefs) > 0 {
+		record.Owner = ownerRefs[0].Kind + "/" + ownerRefs[0].Name
+	}
+	if err := pe.auditSink.Write(record); err != nil {
+		klog.ErrorS(err, "Unable to write audit record", "pod", klog.KObj(pod))
+	}
+}
+
+// nextEvictionRetry takes the eviction due to be retried first out of the queue
+func (pe *PodEvictor) nextEvictionRetry() (evictionRetry, bool) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	if len(pe.retryQueue) == 0 {
+		return evictionRetry{}, false
+	}
+	next := 0
+	for i := range pe.retryQueue {
+		if pe.retryQueue[i].notBefore.Before(pe.retryQueue[next].notBefore) {
+			next = i
+		}
+	}
+	retry := pe.retryQueue[next]
+	pe.retryQueue = append(pe.retryQueue[:next], pe.retryQueue[next+1:]...)
+	return retry, true
+}
+
+// queueEvictionRetry queues the eviction to be retried, unless it ran out of attempts or the queue is full.
+// It is called with mu held.
+func (pe *PodEvictor) queueEvictionRetry(pod *v1.Pod, node *v1.Node, strategy, reason string, attempts int) bool {
+	if attempts >= pe.maxEvictionRetries || len(pe.retryQueue) >= maxEvictionRetryQueueSize {
+		return false
+	}
+	backoff := pe.evictionRetryBackoff << uint(attempts)
+	if backoff > maxEvictionRetryBackoff || backoff <= 0 {
+		backoff = maxEvictionRetryBackoff
+	}
+	pe.retryQueue = append(pe.retryQueue, evictionRetry{
+		pod:       pod,
+		node:      node,
+		strategy:  strategy,
+		reason:    reason,
+		attempts:  attempts + 1,
+		notBefore: time.Now().Add(backoff),
+	})
+	return true
+}
+
+func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, deleteOptions *metav1.DeleteOptions, dryRun bool) error {
 	if dryRun {
 		return nil
 	}
-	deleteOptions := &metav1.DeleteOptions{}
-	// GracePeriodSeconds ?
-	eviction := &policy.Eviction{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: policyGroupVersion,
-			Kind:       eutils.EvictionKind,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-		},
-		DeleteOptions: deleteOptions,
-	}
-	err := client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	objectMeta := metav1.ObjectMeta{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+	}
+
+	var err error
+	// policy/v1 is preferred by SupportEviction, policy/v1beta1 is kept for the clusters that do not serve it yet
+	if policyGroupVersion == policyv1.SchemeGroupVersion.String() {
+		eviction := &policyv1.Eviction{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: policyGroupVersion,
+				Kind:       eutils.EvictionKind,
+			},
+			ObjectMeta:    objectMeta,
+			DeleteOptions: deleteOptions,
+		}
+		err = client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	} else {
+		eviction := &policyv1beta1.Eviction{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: policyGroupVersion,
+				Kind:       eutils.EvictionKind,
+			},
+			ObjectMeta:    objectMeta,
+			DeleteOptions: deleteOptions,
+		}
+		err = client.PolicyV1beta1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	}
 
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
 		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
@@ -167,9 +694,10 @@ func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, poli
 }
 
 type Options struct {
-	priority      *int32
-	nodeFit       bool
-	labelSelector labels.Selector
+	priority             *int32
+	nodeFit              bool
+	labelSelector        labels.Selector
+	podDisruptionBudgets bool
 }
 
 // WithPriorityThreshold sets a threshold for pod's priority class.
@@ -199,10 +727,46 @@ func WithLabelSelector(labelSelector labels.Selector) func(opts *Options) {
 	}
 }
 
-type constraint func(pod *v1.Pod) error
+// WithPodDisruptionBudgets sets whether or not to consider the PodDisruptionBudgets of a pod
+// when evicting. A pod covered by a PodDisruptionBudget which allows no more disruptions is
+// not evictable. The disruptions used up by the evictions of the PodEvictor are taken into
+// account before the PodDisruptionBudgets are updated. It has no effect unless the PodEvictor
+// is created with WithPodDisruptionBudgetLister.
+func WithPodDisruptionBudgets(podDisruptionBudgets bool) func(opts *Options) {
+	return func(opts *Options) {
+		opts.podDisruptionBudgets = podDisruptionBudgets
+	}
+}
+
+// constraint is a named check of Evictable, failing with the reason the pod is not evictable
+type constraint struct {
+	name  string
+	check func(pod *v1.Pod) error
+}
 
 type evictable struct {
-	constraints []constraint
+	podEvictor           *PodEvictor
+	constraints          []constraint
+	disruptionConstraint *constraint
+}
+
+// ConstraintResult is the outcome of one of the constraints of Evictable for a pod
+type ConstraintResult struct {
+	// Constraint is the name of the constraint, e.g. "LocalStorage"
+	Constraint string
+	// Err is the reason the pod fails the constraint, nil when it passes
+	Err error
+}
+
+// Explanation details why a pod is or is not evictable
+type Explanation struct {
+	// Evictable tells whether IsEvictable returns true for the pod
+	Evictable bool
+	// Constraints holds the outcome of every constraint checked, in the order they are checked
+	Constraints []ConstraintResult
+	// EvictAnnotation tells whether the pod has the evict annotation, overriding
+	// all the constraints but the PodDisruptionBudget one
+	EvictAnnotation bool
 }
 
 // Evictable provides an implementation of IsEvictable(IsEvictable(pod *v1.Pod) bool).
@@ -214,90 +778,177 @@ func (pe *PodEvictor) Evictable(opts ...func(opts *Options)) *evictable {
 		opt(options)
 	}
 
-	ev := &evictable{}
+	ev := &evictable{podEvictor: pe}
+	ev.constraints = append(ev.constraints,
+		constraint{name: "DaemonSet", check: func(pod *v1.Pod) error {
+			if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) {
+				return fmt.Errorf("pod is a DaemonSet pod")
+			}
+			return nil
+		}},
+		constraint{name: "OwnerReferences", check: func(pod *v1.Pod) error {
+			if len(podutil.OwnerRef(pod)) == 0 {
+				return fmt.Errorf("pod does not have any ownerrefs")
+			}
+			return nil
+		}},
+		constraint{name: "MirrorPod", check: func(pod *v1.Pod) error {
+			if utils.IsMirrorPod(pod) {
+				return fmt.Errorf("pod is a mirror pod")
+			}
+			return nil
+		}},
+		constraint{name: "StaticPod", check: func(pod *v1.Pod) error {
+			if utils.IsStaticPod(pod) {
+				return fmt.Errorf("pod is a static pod")
+			}
+			return nil
+		}},
+	)
 	if !pe.evictSystemCriticalPods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+		ev.constraints = append(ev.constraints, constraint{name: "SystemCriticalPriority", check: func(pod *v1.Pod) error {
 			// Moved from IsEvictable function to allow for disabling
 			if utils.IsCriticalPriorityPod(pod) {
 				return fmt.Errorf("pod has system critical priority")
 			}
 			return nil
-		})
+		}})
 
 		if options.priority != nil {
-			ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+			ev.constraints = append(ev.constraints, constraint{name: "PriorityThreshold", check: func(pod *v1.Pod) error {
 				if IsPodEvictableBasedOnPriority(pod, *options.priority) {
 					return nil
 				}
 				return fmt.Errorf("pod has higher priority than specified priority class threshold")
-			})
+			}})
 		}
 	}
 	if !pe.evictLocalStoragePods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+		ev.constraints = append(ev.constraints, constraint{name: "LocalStorage", check: func(pod *v1.Pod) error {
 			if utils.IsPodWithLocalStorage(pod) {
 				return fmt.Errorf("pod has local storage and descheduler is not configured with evictLocalStoragePods")
 			}
 			return nil
-		})
+		}})
 	}
 	if pe.ignorePvcPods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+		ev.constraints = append(ev.constraints, constraint{name: "PVC", check: func(pod *v1.Pod) error {
 			if utils.IsPodWithPVC(pod) {
 				return fmt.Errorf("pod has a PVC and descheduler is configured to ignore PVC pods")
 			}
 			return nil
-		})
+		}})
 	}
 	if options.nodeFit {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+		ev.constraints = append(ev.constraints, constraint{name: "NodeFit", check: func(pod *v1.Pod) error {
 			if !nodeutil.PodFitsAnyOtherNode(pod, pe.nodes) {
 				return fmt.Errorf("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable")
 			}
 			return nil
-		})
+		}})
 	}
 	if options.labelSelector != nil && !options.labelSelector.Empty() {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+		ev.constraints = append(ev.constraints, constraint{name: "LabelSelector", check: func(pod *v1.Pod) error {
 			if !options.labelSelector.Matches(labels.Set(pod.Labels)) {
 				return fmt.Errorf("pod labels do not match the labelSelector filter in the policy parameter")
 			}
 			return nil
-		})
+		}})
+	}
+
+	if options.podDisruptionBudgets && pe.pdbLister != nil {
+		ev.disruptionConstraint = &constraint{name: "PodDisruptionBudget", check: pe.checkPodDisruptionBudgets}
 	}
 
 	return ev
 }
 
-// IsEvictable decides when a pod is evictable
-func (ev *evictable) IsEvictable(pod *v1.Pod) bool {
-	checkErrs := []error{}
+// checkPodDisruptionBudgets errors when a PodDisruptionBudget covering the pod allows no more disruptions
+func (pe *PodEvictor) checkPodDisruptionBudgets(pod *v1.Pod) error {
+	pdbs, err := pe.pdbLister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("unable to list PodDisruptionBudgets: %v", err)
+	}
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.checkDisruptionsAllowed(pod, pdbs)
+}
 
-	ownerRefList := podutil.OwnerRef(pod)
-	if utils.IsDaemonsetPod(ownerRefList) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is a DaemonSet pod"))
+// checkDisruptionsAllowed errors when one of the PodDisruptionBudgets covering the pod allows no more
+// disruptions, accounting for the pods evicted or being evicted. It is called with mu held.
+func (pe *PodEvictor) checkDisruptionsAllowed(pod *v1.Pod, pdbs []*policyv1.PodDisruptionBudget) error {
+	for _, pdb := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		disruptionsAllowed := pdb.Status.DisruptionsAllowed
+		for _, evicted := range pe.evictedPods[pod.Namespace] {
+			// the disruptions listed in the status are already accounted for in the allowed ones
+			if _, ok := pdb.Status.DisruptedPods[evicted.Name]; ok {
+				continue
+			}
+			if selector.Matches(labels.Set(evicted.Labels)) {
+				disruptionsAllowed--
+			}
+		}
+		if disruptionsAllowed <= 0 {
+			return fmt.Errorf("pod disruption budget %v allows no more disruptions", pdb.Name)
+		}
 	}
+	return nil
+}
 
-	if len(ownerRefList) == 0 {
-		checkErrs = append(checkErrs, fmt.Errorf("pod does not have any ownerrefs"))
+// Explain checks all the constraints for the pod, unlike IsEvictable which stops at the
+// PodDisruptionBudget constraint when it fails.
+func (ev *evictable) Explain(pod *v1.Pod) Explanation {
+	explanation := Explanation{Evictable: true, EvictAnnotation: HaveEvictAnnotation(pod)}
+
+	if ev.disruptionConstraint != nil {
+		err := ev.disruptionConstraint.check(pod)
+		explanation.Constraints = append(explanation.Constraints, ConstraintResult{Constraint: ev.disruptionConstraint.name, Err: err})
+		// the eviction annotation does not override PodDisruptionBudgets, the eviction would be rejected anyway
+		if err != nil {
+			explanation.Evictable = false
+		}
 	}
 
-	if utils.IsMirrorPod(pod) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is a mirror pod"))
+	for _, c := range ev.constraints {
+		err := c.check(pod)
+		explanation.Constraints = append(explanation.Constraints, ConstraintResult{Constraint: c.name, Err: err})
+		if err != nil && !explanation.EvictAnnotation {
+			explanation.Evictable = false
+		}
 	}
 
-	if utils.IsStaticPod(pod) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is a static pod"))
+	return explanation
+}
+
+// IsEvictable decides when a pod is evictable
+func (ev *evictable) IsEvictable(pod *v1.Pod) bool {
+	if ev.disruptionConstraint != nil {
+		// the eviction annotation does not override PodDisruptionBudgets, the eviction would be rejected anyway
+		if err := ev.disruptionConstraint.check(pod); err != nil {
+			klog.V(4).InfoS("Pod is not evictable", "pod", klog.KObj(pod), "err", err)
+			ev.podEvictor.countSkipped(ev.disruptionConstraint.name, pod)
+			return false
+		}
 	}
 
+	checkErrs := []error{}
+	var failed string
 	for _, c := range ev.constraints {
-		if err := c(pod); err != nil {
+		if err := c.check(pod); err != nil {
 			checkErrs = append(checkErrs, err)
+			if failed == "" {
+				failed = c.name
+			}
 		}
 	}
 
 	if len(checkErrs) > 0 && !HaveEvictAnnotation(pod) {
 		klog.V(4).InfoS("Pod lacks an eviction annotation and fails the following checks", "pod", klog.KObj(pod), "checks", errors.NewAggregate(checkErrs).Error())
+		ev.podEvictor.countSkipped(failed, pod)
 		return false
 	}
 
@@ -315,6 +966,16 @@ func IsPodEvictableBasedOnPriority(pod *v1.Pod, priority int32) bool {
 	return pod.Spec.Priority == nil || *pod.Spec.Priority < priority
 }
 
-func  (pe *PodEvictor)Client()clientset.Interface{
+func (pe *PodEvictor) Client() clientset.Interface {
 	return pe.client
-}
\ No newline at end of file
+}
+
+// EventRecorder gives the recorder the PodEvictor records events with
+func (pe *PodEvictor) EventRecorder() record.EventRecorder {
+	return pe.eventRecorder
+}
+
+// MetricsClient gives the client of the metrics.k8s.io API, nil when not configured
+func (pe *PodEvictor) MetricsClient() metricsclientset.Interface {
+	return pe.metricsClient
+}
@@ -18,13 +18,27 @@ package evictions
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -62,13 +76,374 @@ func TestEvictPod(t *testing.T) {
 		fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
 			return true, &v1.PodList{Items: test.pods}, nil
 		})
-		got := evictPod(ctx, fakeClient, test.pod, "v1", false)
+		got := evictPod(ctx, fakeClient, test.pod, "v1", &metav1.DeleteOptions{}, false)
 		if got != test.want {
 			t.Errorf("Test error for Desc: %s. Expected %v pod eviction to be %v, got %v", test.description, test.pod.Name, test.want, got)
 		}
 	}
 }
 
+func TestEvictPodPolicyGroupVersion(t *testing.T) {
+	ctx := context.Background()
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	tests := []struct {
+		description        string
+		policyGroupVersion string
+		expected           runtime.Object
+	}{
+		{
+			description:        "policy/v1 eviction",
+			policyGroupVersion: "policy/v1",
+			expected:           &policyv1.Eviction{},
+		},
+		{
+			description:        "policy/v1beta1 eviction",
+			policyGroupVersion: "policy/v1beta1",
+			expected:           &policyv1beta1.Eviction{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var eviction runtime.Object
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					eviction = action.(core.CreateAction).GetObject()
+				}
+				return true, nil, nil
+			})
+			if err := evictPod(ctx, fakeClient, pod1, test.policyGroupVersion, &metav1.DeleteOptions{}, false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if reflect.TypeOf(eviction) != reflect.TypeOf(test.expected) {
+				t.Fatalf("Expected %T to be created, got %T", test.expected, eviction)
+			}
+			if apiVersion := eviction.GetObjectKind().GroupVersionKind().GroupVersion().String(); apiVersion != test.policyGroupVersion {
+				t.Errorf("Expected eviction of %v apiVersion, got %v", test.policyGroupVersion, apiVersion)
+			}
+		})
+	}
+}
+
+func TestEvictPodDeleteOptions(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+	policyGracePeriod := int64(30)
+	strategyGracePeriod := int64(60)
+	background := metav1.DeletePropagationBackground
+
+	var deleteOptions *metav1.DeleteOptions
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			deleteOptions = action.(core.CreateAction).GetObject().(*policyv1.Eviction).DeleteOptions
+		}
+		return true, nil, nil
+	})
+
+	podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, 0, 0, 0, []*v1.Node{node1}, false, false, false,
+		WithEvictionGracePeriodSeconds(&policyGracePeriod),
+		WithPropagationPolicy(&background),
+	)
+
+	if _, err := podEvictor.EvictPod(ctx, pod1, node1, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleteOptions == nil || *deleteOptions.GracePeriodSeconds != policyGracePeriod || *deleteOptions.PropagationPolicy != background {
+		t.Errorf("Expected pod to be evicted with %v grace period and %v propagation policy, got %+v", policyGracePeriod, background, deleteOptions)
+	}
+
+	podEvictor.SetStrategyOptions(StrategyOptions{EvictionGracePeriodSeconds: &strategyGracePeriod})
+	if _, err := podEvictor.EvictPod(ctx, pod1, node1, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deleteOptions == nil || *deleteOptions.GracePeriodSeconds != strategyGracePeriod {
+		t.Errorf("Expected pod to be evicted with the %v grace period of the strategy, got %+v", strategyGracePeriod, deleteOptions)
+	}
+}
+
+func TestEvictPodRetriesTooManyRequests(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	tests := []struct {
+		description      string
+		rejections       int
+		maxRetries       int
+		expectedEvicted  int
+		expectedAttempts int
+	}{
+		{
+			description:      "eviction is not retried by default",
+			rejections:       1,
+			expectedEvicted:  0,
+			expectedAttempts: 1,
+		},
+		{
+			description:      "eviction succeeds on retry",
+			rejections:       2,
+			maxRetries:       3,
+			expectedEvicted:  1,
+			expectedAttempts: 3,
+		},
+		{
+			description:      "eviction runs out of retries",
+			rejections:       5,
+			maxRetries:       2,
+			expectedEvicted:  0,
+			expectedAttempts: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod1 := test.BuildTestPod("p1", 400, 0, "node1", nil)
+			attempts := 0
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				attempts++
+				if attempts <= tc.rejections {
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+				}
+				return true, nil, nil
+			})
+
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", false, 0, 0, 0, []*v1.Node{node1}, false, false, false,
+				WithEvictionRetries(tc.maxRetries, time.Millisecond),
+			)
+			if evicted, err := podEvictor.EvictPod(ctx, pod1, node1, "test"); err != nil || evicted {
+				t.Fatalf("Expected the first eviction to be rejected, got %v, %v", evicted, err)
+			}
+			podEvictor.RetryEvictions(ctx)
+
+			if podEvictor.TotalEvicted() != tc.expectedEvicted {
+				t.Errorf("Expected %v evicted pods, got %v", tc.expectedEvicted, podEvictor.TotalEvicted())
+			}
+			if attempts != tc.expectedAttempts {
+				t.Errorf("Expected %v eviction attempts, got %v", tc.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestEvictPodEvents(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+
+	tests := []struct {
+		description    string
+		dryRun         bool
+		evictionErr    error
+		evictions      int
+		expectedReason string
+	}{
+		{
+			description:    "evicted pod",
+			evictions:      1,
+			expectedReason: EventReasonDescheduled,
+		},
+		{
+			description:    "pod evicted in dry run mode",
+			dryRun:         true,
+			evictions:      1,
+			expectedReason: EventReasonDescheduledDryRun,
+		},
+		{
+			description:    "failed eviction",
+			evictionErr:    fmt.Errorf("eviction failed"),
+			evictions:      1,
+			expectedReason: EventReasonDescheduleFailed,
+		},
+		{
+			description:    "eviction skipped because of the node limit",
+			evictions:      2,
+			expectedReason: EventReasonDescheduleSkipped,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, tc.evictionErr
+			})
+			recorder := record.NewFakeRecorder(tc.evictions)
+			podEvictor := NewPodEvictor(fakeClient, "policy/v1", tc.dryRun, 1, 0, 0, []*v1.Node{node1}, false, false, false,
+				WithEventRecorder(recorder),
+			)
+
+			for i := 0; i < tc.evictions; i++ {
+				podEvictor.EvictPod(ctx, test.BuildTestPod(fmt.Sprintf("p%v", i), 100, 0, node1.Name, nil), node1, "test")
+			}
+
+			var event string
+			for len(recorder.Events) > 0 {
+				event = <-recorder.Events
+			}
+			if !strings.Contains(event, " "+tc.expectedReason+" ") {
+				t.Errorf("Expected the last event to have the %v reason, got %q", tc.expectedReason, event)
+			}
+		})
+	}
+}
+
+type fakeAuditSink struct {
+	records []audit.Record
+}
+
+func (s *fakeAuditSink) Write(record audit.Record) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *fakeAuditSink) Close() error {
+	return nil
+}
+
+func TestEvictPodAudit(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	sink := &fakeAuditSink{}
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", true, 1, 0, 0, []*v1.Node{node1}, false, false, false,
+		WithAuditSink(sink),
+	)
+
+	for i := 0; i < 2; i++ {
+		pod := test.BuildTestPod(fmt.Sprintf("p%v", i), 100, 0, node1.Name, nil)
+		pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+		podEvictor.EvictPod(ctx, pod, node1, "PodLifeTime", "too old")
+	}
+
+	expected := []audit.Record{
+		{Strategy: "PodLifeTime", Reason: "PodLifeTime (too old)", Pod: "default/p0", Node: "node1", Owner: "ReplicaSet/replicaset-1", DryRun: true, Result: "success"},
+		{Strategy: "PodLifeTime", Reason: "PodLifeTime (too old)", Pod: "default/p1", Node: "node1", Owner: "ReplicaSet/replicaset-1", DryRun: true, Result: "maximum number reached"},
+	}
+	for i := range sink.records {
+		if sink.records[i].Timestamp.IsZero() {
+			t.Errorf("Expected audit record %v to have a timestamp", i)
+		}
+		sink.records[i].Timestamp = time.Time{}
+	}
+	if !reflect.DeepEqual(sink.records, expected) {
+		t.Errorf("Expected audit records %+v, got %+v", expected, sink.records)
+	}
+}
+
+func TestEvictPodStrategyLimit(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+	fakeClient := &fake.Clientset{}
+
+	podEvictor := NewPodEvictor(fakeClient, "v1", true, 3, 0, 0, []*v1.Node{node1, node2}, false, false, false)
+	podEvictor.SetStrategyOptions(StrategyOptions{MaxPodsToEvictPerNode: 2})
+	for i, want := range []bool{true, true, false} {
+		pod := test.BuildTestPod(fmt.Sprintf("p%v", i), 100, 0, node1.Name, nil)
+		if got, _ := podEvictor.EvictPod(ctx, pod, node1, "first"); got != want {
+			t.Errorf("Expected eviction of %v by the first strategy to be %v, got %v", pod.Name, want, got)
+		}
+	}
+	pod := test.BuildTestPod("p", 100, 0, node2.Name, nil)
+	if got, _ := podEvictor.EvictPod(ctx, pod, node2, "first"); !got {
+		t.Errorf("Expected the strategy limit to be counted per node")
+	}
+
+	// the next strategy starts with its own limit, restricted by what is left of the overall limit
+	podEvictor.SetStrategyOptions(StrategyOptions{})
+	for i, want := range []bool{true, false} {
+		pod := test.BuildTestPod(fmt.Sprintf("p%v", i+10), 100, 0, node1.Name, nil)
+		if got, _ := podEvictor.EvictPod(ctx, pod, node1, "second"); got != want {
+			t.Errorf("Expected eviction of %v by the second strategy to be %v, got %v", pod.Name, want, got)
+		}
+	}
+	if podEvictor.TotalEvicted() != 4 {
+		t.Errorf("Expected 4 pods to be evicted in total, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodNamespaceAndTotalLimits(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	fakeClient := &fake.Clientset{}
+
+	podEvictor := NewPodEvictor(fakeClient, "v1", true, 0, 2, 3, []*v1.Node{node1}, false, false, false)
+	tests := []struct {
+		namespace string
+		want      bool
+	}{
+		{namespace: "ns1", want: true},
+		{namespace: "ns1", want: true},
+		{namespace: "ns1", want: false},
+		{namespace: "ns2", want: true},
+		{namespace: "ns3", want: false},
+	}
+	for i, tc := range tests {
+		pod := test.BuildTestPod(fmt.Sprintf("p%v", i), 100, 0, node1.Name, func(pod *v1.Pod) {
+			pod.Namespace = tc.namespace
+		})
+		got, err := podEvictor.EvictPod(ctx, pod, node1, "test")
+		if got != tc.want {
+			t.Errorf("Expected eviction of %v in %v namespace to be %v, got %v", pod.Name, tc.namespace, tc.want, got)
+		}
+		if !got && err == nil {
+			t.Errorf("Expected an error when the eviction of %v is not allowed", pod.Name)
+		}
+	}
+	if podEvictor.NamespaceEvicted("ns1") != 2 {
+		t.Errorf("Expected 2 pods to be evicted in ns1 namespace, got %v", podEvictor.NamespaceEvicted("ns1"))
+	}
+	if podEvictor.TotalEvicted() != 3 {
+		t.Errorf("Expected 3 pods to be evicted in total, got %v", podEvictor.TotalEvicted())
+	}
+}
+
+func TestEvictPodConcurrently(t *testing.T) {
+	ctx := context.Background()
+	var nodes []*v1.Node
+	for i := 0; i < 4; i++ {
+		nodes = append(nodes, test.BuildTestNode(fmt.Sprintf("node%v", i), 1000, 2000, 20, nil))
+	}
+	fakeClient := &fake.Clientset{}
+	// the first eviction of every node fails, the limits are not used up by failed evictions
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" && strings.HasSuffix(action.(core.CreateAction).GetObject().(*policyv1.Eviction).Name, "-0") {
+			return true, nil, fmt.Errorf("eviction failed")
+		}
+		return false, nil, nil
+	})
+
+	podEvictor := NewPodEvictor(fakeClient, policyv1.SchemeGroupVersion.String(), false, 3, 0, 10, nodes, false, false, false)
+
+	done := make(chan struct{})
+	for _, node := range nodes {
+		go func(node *v1.Node) {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < 5; i++ {
+				pod := test.BuildTestPod(fmt.Sprintf("%v-%v", node.Name, i), 100, 0, node.Name, nil)
+				podEvictor.EvictPod(ctx, pod, node, "test")
+			}
+		}(node)
+	}
+	for range nodes {
+		<-done
+	}
+
+	if podEvictor.TotalEvicted() != 10 {
+		t.Errorf("Expected 10 pods to be evicted in total, got %v", podEvictor.TotalEvicted())
+	}
+	for _, node := range nodes {
+		if evicted := podEvictor.NodeEvicted(node); evicted > 3 {
+			t.Errorf("Expected at most 3 pods to be evicted on %v, got %v", node.Name, evicted)
+		}
+	}
+	stats := podEvictor.StrategyStats()
+	if stats.Candidates != 20 || stats.Evicted != 10 || len(stats.Errors) != 4 {
+		t.Errorf("Expected 20 candidates, 10 evicted pods and 4 errors, got %+v", stats)
+	}
+}
+
 func TestIsEvictable(t *testing.T) {
 	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
 	lowPriority := int32(800)
@@ -437,6 +812,238 @@ func TestIsEvictable(t *testing.T) {
 
 	}
 }
+
+func TestIsEvictablePodDisruptionBudgets(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	newPod := func(name string, labels map[string]string) *v1.Pod {
+		pod := test.BuildTestPod(name, 100, 0, node1.Name, nil)
+		pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+		pod.Labels = labels
+		return pod
+	}
+	newPDB := func(name string, labels map[string]string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		minAvailable := intstr.FromInt(1)
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+				Selector:     &metav1.LabelSelector{MatchLabels: labels},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pdb := range []*policyv1.PodDisruptionBudget{
+		newPDB("exhausted", map[string]string{"app": "exhausted"}, 0),
+		newPDB("allows-one", map[string]string{"app": "allows-one"}, 1),
+		newPDB("allows-two", map[string]string{"app": "allows-two"}, 2),
+	} {
+		if err := indexer.Add(pdb); err != nil {
+			t.Fatalf("Unable to add PodDisruptionBudget: %v", err)
+		}
+	}
+
+	exhausted := newPod("exhausted", map[string]string{"app": "exhausted"})
+	exhausted.Annotations = map[string]string{evictPodAnnotationKey: "true"}
+	allowsOne1 := newPod("allows-one-1", map[string]string{"app": "allows-one"})
+	allowsOne2 := newPod("allows-one-2", map[string]string{"app": "allows-one"})
+	uncovered := newPod("uncovered", map[string]string{"app": "uncovered"})
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", true, 0, 0, 0, []*v1.Node{node1}, false, false, false,
+		WithPodDisruptionBudgetLister(policyv1listers.NewPodDisruptionBudgetLister(indexer)),
+	)
+
+	if !podEvictor.Evictable().IsEvictable(exhausted) {
+		t.Errorf("Expected PodDisruptionBudgets to be ignored unless requested")
+	}
+
+	evictable := podEvictor.Evictable(WithPodDisruptionBudgets(true))
+	for _, tc := range []struct {
+		pod       *v1.Pod
+		evictable bool
+	}{
+		{pod: exhausted, evictable: false},
+		{pod: allowsOne1, evictable: true},
+		{pod: allowsOne2, evictable: true},
+		{pod: uncovered, evictable: true},
+	} {
+		if got := evictable.IsEvictable(tc.pod); got != tc.evictable {
+			t.Errorf("Expected pod %v to be evictable: %v, got %v", tc.pod.Name, tc.evictable, got)
+		}
+	}
+
+	if _, err := podEvictor.EvictPod(ctx, allowsOne1, node1, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if evictable.IsEvictable(allowsOne2) {
+		t.Errorf("Expected the eviction of %v to use up the disruption allowed by the PodDisruptionBudget", allowsOne1.Name)
+	}
+
+	// once the status of the PodDisruptionBudget accounts for an eviction, the eviction is not counted again
+	allowsTwo1 := newPod("allows-two-1", map[string]string{"app": "allows-two"})
+	allowsTwo2 := newPod("allows-two-2", map[string]string{"app": "allows-two"})
+	if _, err := podEvictor.EvictPod(ctx, allowsTwo1, node1, "test"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	updated := newPDB("allows-two", map[string]string{"app": "allows-two"}, 1)
+	updated.Status.DisruptedPods = map[string]metav1.Time{allowsTwo1.Name: metav1.Now()}
+	if err := indexer.Update(updated); err != nil {
+		t.Fatalf("Unable to update PodDisruptionBudget: %v", err)
+	}
+	if !evictable.IsEvictable(allowsTwo2) {
+		t.Errorf("Expected pod %v to be evictable once the PodDisruptionBudget status reflects the eviction of %v", allowsTwo2.Name, allowsTwo1.Name)
+	}
+}
+
+func TestEvictPodPodDisruptionBudgetsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	nodes := []*v1.Node{
+		test.BuildTestNode("node1", 1000, 2000, 9, nil),
+		test.BuildTestNode("node2", 1000, 2000, 9, nil),
+	}
+	minAvailable := intstr.FromInt(1)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "allows-one", Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "allows-one"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}); err != nil {
+		t.Fatalf("Unable to add PodDisruptionBudget: %v", err)
+	}
+
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", false, 0, 0, 0, nodes, false, false, false,
+		WithPodDisruptionBudgetLister(policyv1listers.NewPodDisruptionBudgetLister(indexer)),
+	)
+	evictable := podEvictor.Evictable(WithPodDisruptionBudgets(true))
+
+	// the pods of both nodes are found evictable before any of them is evicted
+	var checked, evicted sync.WaitGroup
+	checked.Add(len(nodes))
+	evicted.Add(len(nodes))
+	for _, node := range nodes {
+		go func(node *v1.Node) {
+			defer evicted.Done()
+			pod := test.BuildTestPod(node.Name+"-pod", 100, 0, node.Name, nil)
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			pod.Labels = map[string]string{"app": "allows-one"}
+			isEvictable := evictable.IsEvictable(pod)
+			checked.Done()
+			checked.Wait()
+			if !isEvictable {
+				t.Errorf("Expected pod %v to be evictable", pod.Name)
+				return
+			}
+			if _, err := podEvictor.EvictPod(ctx, pod, node, "test"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}(node)
+	}
+	evicted.Wait()
+
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected the PodDisruptionBudget to allow 1 eviction, got %v", podEvictor.TotalEvicted())
+	}
+	if stats := podEvictor.StrategyStats(); stats.Skipped["PodDisruptionBudget"] != 1 {
+		t.Errorf("Expected 1 pod to be skipped because of the PodDisruptionBudget, got %+v", stats)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	minAvailable := intstr.FromInt(1)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "exhausted", Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "exhausted"}},
+		},
+	}); err != nil {
+		t.Fatalf("Unable to add PodDisruptionBudget: %v", err)
+	}
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", true, 0, 0, 0, []*v1.Node{node1}, false, false, false,
+		WithPodDisruptionBudgetLister(policyv1listers.NewPodDisruptionBudgetLister(indexer)),
+	)
+	evictable := podEvictor.Evictable(WithPriorityThreshold(100), WithPodDisruptionBudgets(true))
+
+	failed := func(explanation Explanation) []string {
+		var names []string
+		for _, result := range explanation.Constraints {
+			if result.Err != nil {
+				names = append(names, result.Constraint)
+			}
+		}
+		return names
+	}
+
+	localStorage := test.BuildTestPod("local-storage", 100, 0, node1.Name, nil)
+	localStorage.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+	localStorage.Spec.Volumes = []v1.Volume{{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+	priority := int32(200)
+	localStorage.Spec.Priority = &priority
+
+	explanation := evictable.Explain(localStorage)
+	if explanation.Evictable || explanation.EvictAnnotation {
+		t.Errorf("Expected pod %v not to be evictable, got %+v", localStorage.Name, explanation)
+	}
+	if got, expected := failed(explanation), []string{"PriorityThreshold", "LocalStorage"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected failed constraints %v, got %v", expected, got)
+	}
+	if len(explanation.Constraints) != 8 {
+		t.Errorf("Expected all the constraints to be checked, got %+v", explanation.Constraints)
+	}
+	if explanation.Evictable != evictable.IsEvictable(localStorage) {
+		t.Errorf("Expected Explain to agree with IsEvictable")
+	}
+
+	localStorage.Annotations = map[string]string{evictPodAnnotationKey: "true"}
+	if explanation := evictable.Explain(localStorage); !explanation.Evictable || !explanation.EvictAnnotation {
+		t.Errorf("Expected the evict annotation to override the failed constraints, got %+v", explanation)
+	}
+
+	// the evict annotation does not override the PodDisruptionBudget constraint, the other constraints are checked anyway
+	localStorage.Labels = map[string]string{"app": "exhausted"}
+	explanation = evictable.Explain(localStorage)
+	if explanation.Evictable {
+		t.Errorf("Expected pod %v not to be evictable, got %+v", localStorage.Name, explanation)
+	}
+	if got, expected := failed(explanation), []string{"PodDisruptionBudget", "PriorityThreshold", "LocalStorage"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected failed constraints %v, got %v", expected, got)
+	}
+}
+
+func TestStrategyStats(t *testing.T) {
+	ctx := context.Background()
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef)
+	p2 := test.BuildTestPod("p2", 100, 0, node1.Name, test.SetRSOwnerRef)
+	podEvictor := NewPodEvictor(&fake.Clientset{}, "policy/v1", true, 1, 0, 0, []*v1.Node{node1}, false, false, false)
+
+	for _, pod := range []*v1.Pod{p1, p2} {
+		podEvictor.EvictPod(ctx, pod, node1, "test")
+	}
+	podEvictor.CountDeletion(p2, fmt.Errorf("conflict"))
+	expected := StrategyStats{
+		Candidates: 3,
+		Evicted:    1,
+		Skipped:    map[string]int{"maximum number reached": 1},
+		Errors:     []string{"default/p2: conflict"},
+	}
+	if got := podEvictor.StrategyStats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected stats %+v, got %+v", expected, got)
+	}
+
+	podEvictor.SetStrategyOptions(StrategyOptions{})
+	if got := podEvictor.StrategyStats(); !reflect.DeepEqual(got, StrategyStats{Skipped: map[string]int{}}) {
+		t.Errorf("Expected the stats to be reset for the next strategy, got %+v", got)
+	}
+}
+
 func TestPodTypes(t *testing.T) {
 	n1 := test.BuildTestNode("node1", 1000, 2000, 9, nil)
 	p1 := test.BuildTestPod("p1", 400, 0, n1.Name, nil)
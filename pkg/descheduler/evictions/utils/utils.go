@@ -17,6 +17,9 @@ limitations under the License.
 package utils
 
 import (
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
 )
 
@@ -27,6 +30,7 @@ const (
 
 // SupportEviction uses Discovery API to find out if the server support eviction subresource
 // If support, it will return its groupVersion; Otherwise, it will return ""
+// policy/v1 is returned whenever the server serves it, then policy/v1beta1, then the preferred version.
 func SupportEviction(client clientset.Interface) (string, error) {
 	discoveryClient := client.Discovery()
 	groupList, err := discoveryClient.ServerGroups()
@@ -36,9 +40,9 @@ func SupportEviction(client clientset.Interface) (string, error) {
 	foundPolicyGroup := false
 	var policyGroupVersion string
 	for _, group := range groupList.Groups {
-		if group.Name == "policy" {
+		if group.Name == policyv1.GroupName {
 			foundPolicyGroup = true
-			policyGroupVersion = group.PreferredVersion.GroupVersion
+			policyGroupVersion = preferredPolicyGroupVersion(group.Versions, group.PreferredVersion.GroupVersion)
 			break
 		}
 	}
@@ -56,3 +60,15 @@ func SupportEviction(client clientset.Interface) (string, error) {
 	}
 	return "", nil
 }
+
+// preferredPolicyGroupVersion picks the policy group version the descheduler can evict pods with
+func preferredPolicyGroupVersion(versions []metav1.GroupVersionForDiscovery, preferredVersion string) string {
+	for _, groupVersion := range []string{policyv1.SchemeGroupVersion.String(), policyv1beta1.SchemeGroupVersion.String()} {
+		for _, version := range versions {
+			if version.GroupVersion == groupVersion {
+				return groupVersion
+			}
+		}
+	}
+	return preferredVersion
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSupportEviction(t *testing.T) {
+	coreResources := &metav1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod"},
+			{Name: EvictionSubresource, Kind: EvictionKind},
+		},
+	}
+
+	tests := []struct {
+		description string
+		resources   []*metav1.APIResourceList
+		expected    string
+	}{
+		{
+			description: "policy/v1 is preferred even when it is not the preferred version of the server",
+			resources: []*metav1.APIResourceList{
+				coreResources,
+				{GroupVersion: "policy/v1beta1"},
+				{GroupVersion: "policy/v1"},
+			},
+			expected: "policy/v1",
+		},
+		{
+			description: "fallback to policy/v1beta1 when policy/v1 is not served",
+			resources: []*metav1.APIResourceList{
+				coreResources,
+				{GroupVersion: "policy/v1beta1"},
+			},
+			expected: "policy/v1beta1",
+		},
+		{
+			description: "no policy group",
+			resources: []*metav1.APIResourceList{
+				coreResources,
+			},
+			expected: "",
+		},
+		{
+			description: "no eviction subresource",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod"}}},
+				{GroupVersion: "policy/v1"},
+			},
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			fakeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = test.resources
+
+			got, err := SupportEviction(fakeClient)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != test.expected {
+				t.Errorf("Expected %q policy group version, got %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// StrategyExplanation tells whether a strategy would consider evicting a pod
+type StrategyExplanation struct {
+	Strategy api.StrategyName
+	// Evaluated is false when the strategy selects the pods with criteria of its own, or does not tell
+	// the filters it selects the pods with, see registry.Strategy
+	Evaluated bool
+	// Considered tells whether the pod passes the filters of the strategy and is evictable
+	Considered bool
+	// Reasons are the filters of the strategy parameters the pod fails
+	Reasons []string
+	// Evictable details the Evictable constraints of the strategy for the pod
+	Evictable evictions.Explanation
+}
+
+// ExplainPod tells, for every enabled strategy of the policy in the order they run, whether the
+// strategy would consider evicting the pod. Only the filters shared by the strategies are evaluated,
+// the strategy specific conditions such as the pod age or restarts are not.
+func ExplainPod(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, pod *v1.Pod) ([]StrategyExplanation, error) {
+	sharedInformerFactory := informers.NewSharedInformerFactory(rs.Client, 0)
+	nodeInformer := sharedInformerFactory.Core().V1().Nodes()
+	var evictorOpts []evictions.EvictorOption
+	if evictionPolicyGroupVersion == policyv1.SchemeGroupVersion.String() {
+		pdbLister := sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+		evictorOpts = append(evictorOpts, evictions.WithPodDisruptionBudgetLister(pdbLister))
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	cycle, err := newCycleConfig(rs, deschedulerPolicy, evictorOpts)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := nodeutil.ReadyNodes(ctx, rs.Client, nodeInformer, cycle.nodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get ready nodes: %v", err)
+	}
+	onReadyNode := false
+	for _, node := range nodes {
+		if node.Name == pod.Spec.NodeName {
+			onReadyNode = true
+			break
+		}
+	}
+
+	podEvictor := evictions.NewPodEvictor(
+		rs.Client,
+		evictionPolicyGroupVersion,
+		true,
+		cycle.maxNoOfPodsToEvictPerNode,
+		cycle.maxNoOfPodsToEvictPerNamespace,
+		cycle.maxNoOfPodsToEvictTotal,
+		nodes,
+		cycle.evictLocalStoragePods,
+		cycle.evictSystemCriticalPods,
+		cycle.ignorePvcPods,
+		cycle.evictorOpts...,
+	)
+
+	var explanations []StrategyExplanation
+	for _, name := range cycle.strategyNames {
+		strategy := cycle.policy.Strategies[name]
+		if !strategy.Enabled {
+			continue
+		}
+		explanation := StrategyExplanation{Strategy: name}
+		registered, ok := registry.Lookup(name)
+		if !ok || registered.Filters == nil {
+			explanations = append(explanations, explanation)
+			continue
+		}
+		filters := registered.Filters
+		explanation.Evaluated = true
+
+		params, err := validation.ValidateAndParseStrategyParams(ctx, rs.Client, strategy.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameters of strategy %v: %v", name, err)
+		}
+		if !onReadyNode {
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("node %q is not one of the ready nodes matching the node selector", pod.Spec.NodeName))
+		}
+		if filters.Namespaces {
+			if params.IncludedNamespaces.Len() > 0 && !params.IncludedNamespaces.Has(pod.Namespace) {
+				explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("namespace %q is not included", pod.Namespace))
+			}
+			if params.ExcludedNamespaces.Has(pod.Namespace) {
+				explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("namespace %q is excluded", pod.Namespace))
+			}
+		}
+		if filters.LabelSelector && params.LabelSelector != nil && !params.LabelSelector.Matches(labels.Set(pod.Labels)) {
+			explanation.Reasons = append(explanation.Reasons, "pod labels do not match the labelSelector")
+		}
+
+		opts := []func(opts *evictions.Options){evictions.WithPodDisruptionBudgets(true)}
+		if filters.ThresholdPriority {
+			opts = append(opts, evictions.WithPriorityThreshold(params.ThresholdPriority))
+		}
+		if filters.NodeFit {
+			opts = append(opts, evictions.WithNodeFit(params.NodeFit))
+		}
+		explanation.Evictable = podEvictor.Evictable(opts...).Explain(pod)
+		explanation.Considered = len(explanation.Reasons) == 0 && explanation.Evictable.Evictable
+
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations, nil
+}
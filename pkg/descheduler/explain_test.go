@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestExplainPod(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	pod := test.BuildTestPod("p1", 200, 0, n1.Name, nil)
+	pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+	pod.Labels = map[string]string{"app": "p1"}
+	minAvailable := intstr.FromInt(1)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: pod.Namespace},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: pod.Labels},
+		},
+	}
+
+	maxPodLifeTimeSeconds := uint(600)
+
+	// an out-of-tree strategy not telling its filters is listed, but not explained
+	registry.Register(registry.Strategy{
+		Name: "TestExplainPodOutOfTree",
+		New: func() registry.StrategyFunction {
+			return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+			}
+		},
+	})
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	rs.Client = fakeclientset.NewSimpleClientset(n1, n2, pod, pdb)
+	dp := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"PodLifeTime": api.DeschedulerStrategy{
+				Enabled: true,
+				Weight:  2,
+				Params: &api.StrategyParameters{
+					PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds},
+					Namespaces:  &api.Namespaces{Exclude: []string{pod.Namespace}},
+				},
+			},
+			"RemoveDuplicates":                    api.DeschedulerStrategy{Enabled: true, Weight: 1},
+			"RemoveFailedPods":                    api.DeschedulerStrategy{Enabled: false},
+			"BalancePodsOnNodeForDefragmentation": api.DeschedulerStrategy{Enabled: true},
+			"TestExplainPodOutOfTree":             api.DeschedulerStrategy{Enabled: true},
+		},
+	}
+
+	explanations, err := ExplainPod(ctx, rs, dp, policyv1.SchemeGroupVersion.String(), pod)
+	if err != nil {
+		t.Fatalf("Unable to explain pod: %v", err)
+	}
+
+	var strategies []api.StrategyName
+	for _, explanation := range explanations {
+		strategies = append(strategies, explanation.Strategy)
+	}
+	if expected := []api.StrategyName{"PodLifeTime", "RemoveDuplicates", "BalancePodsOnNodeForDefragmentation", "TestExplainPodOutOfTree"}; !reflect.DeepEqual(strategies, expected) {
+		t.Fatalf("Expected the enabled strategies %v to be explained, got %v", expected, strategies)
+	}
+
+	podLifeTime := explanations[0]
+	if podLifeTime.Considered || !reflect.DeepEqual(podLifeTime.Reasons, []string{`namespace "default" is excluded`}) {
+		t.Errorf("Expected the pod not to be considered by PodLifeTime because of its namespace, got %+v", podLifeTime)
+	}
+
+	removeDuplicates := explanations[1]
+	if removeDuplicates.Considered || len(removeDuplicates.Reasons) != 0 {
+		t.Errorf("Expected the pod not to be considered by RemoveDuplicates because of its PodDisruptionBudget, got %+v", removeDuplicates)
+	}
+	for _, result := range removeDuplicates.Evictable.Constraints {
+		if (result.Err != nil) != (result.Constraint == "PodDisruptionBudget") {
+			t.Errorf("Unexpected result of constraint %v: %v", result.Constraint, result.Err)
+		}
+	}
+
+	for _, explanation := range explanations[2:] {
+		if explanation.Evaluated {
+			t.Errorf("Expected %v not to be evaluated, got %+v", explanation.Strategy, explanation)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework splits a strategy into extension points: the pre-filter deciding whether the
+// strategy may evict a pod at all, the sorter ordering the eviction candidates, and the deschedule or
+// balance plugin picking the pods to evict among the candidates. The pre-filter and the sorter are
+// configured by the parameters shared by the strategies, so a plugin only implements the constraint
+// of its strategy.
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
+)
+
+// Plugin picks the pods a strategy evicts. It is either a DeschedulePlugin or a BalancePlugin.
+type Plugin interface{}
+
+// DeschedulePlugin evicts the pods violating the constraint of a strategy, one node at a time.
+// Up to the node concurrency of the PodEvictor nodes are descheduled in parallel.
+type DeschedulePlugin interface {
+	// Deschedule evicts the pods of node violating the constraint. It may be called for several nodes at once.
+	Deschedule(ctx context.Context, h *Handle, node *v1.Node)
+}
+
+// BalancePlugin evicts pods to even out the nodes, looking at all of them at once
+type BalancePlugin interface {
+	// Balance evicts pods from the nodes
+	Balance(ctx context.Context, h *Handle, nodes []*v1.Node)
+}
+
+// PluginFactory creates the plugin of a strategy, failing when the parameters of the strategy are invalid
+type PluginFactory func(params *api.StrategyParameters) (Plugin, error)
+
+// Option configures the handle of a strategy
+type Option func(opts *options)
+
+type options struct {
+	defaultSortBy  []string
+	withoutNodeFit bool
+	preFilter      func(pod *v1.Pod) bool
+}
+
+// WithDefaultSortBy sets the sorters ordering the candidates of a strategy configured without sortBy.
+// The candidates are not sorted by default.
+func WithDefaultSortBy(names ...string) Option {
+	return func(opts *options) {
+		opts.defaultSortBy = names
+	}
+}
+
+// WithoutNodeFit ignores the nodeFit parameter, for the strategies evicting pods whether they fit another node or not
+func WithoutNodeFit() Option {
+	return func(opts *options) {
+		opts.withoutNodeFit = true
+	}
+}
+
+// WithPreFilter decides which pods the strategy may move instead of the Evictable constraints of the PodEvictor,
+// for the strategies deleting and recreating pods rather than evicting them.
+func WithPreFilter(filter func(pod *v1.Pod) bool) Option {
+	return func(opts *options) {
+		opts.preFilter = filter
+	}
+}
+
+// Handle gives a plugin access to the cluster, and to the pre-filter and sorter of its strategy
+type Handle struct {
+	Client      clientset.Interface
+	PodEvictor  *evictions.PodEvictor
+	PodInformer coreinformers.PodInformer
+	// Nodes are the nodes the strategy runs over
+	Nodes []*v1.Node
+
+	params    *validation.ValidatedStrategyParams
+	listOpts  []func(opts *podutil.Options)
+	evictable func(pod *v1.Pod) bool
+	sort      func(pods []*v1.Pod)
+}
+
+// NewHandle creates the handle of a strategy with the given parameters
+func NewHandle(
+	ctx context.Context,
+	client clientset.Interface,
+	params *api.StrategyParameters,
+	nodes []*v1.Node,
+	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
+	opts ...Option,
+) (*Handle, error) {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	validated, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+
+	sortBy := options.defaultSortBy
+	listOpts := []func(opts *podutil.Options){podutil.WithPodInformer(podInformer)}
+	if params != nil {
+		if len(params.SortBy) > 0 {
+			sortBy = params.SortBy
+		}
+		if params.Namespaces != nil {
+			listOpts = append(listOpts,
+				podutil.WithNamespaces(params.Namespaces.Include),
+				podutil.WithoutNamespaces(params.Namespaces.Exclude),
+			)
+		}
+		listOpts = append(listOpts, podutil.WithLabelSelector(params.LabelSelector))
+	}
+	sort, err := NewSorter(sortBy...)
+	if err != nil {
+		return nil, err
+	}
+
+	evictable := options.preFilter
+	if evictable == nil {
+		evictable = podEvictor.Evictable(
+			evictions.WithPriorityThreshold(validated.ThresholdPriority),
+			evictions.WithNodeFit(validated.NodeFit && !options.withoutNodeFit),
+			evictions.WithPodDisruptionBudgets(true),
+		).IsEvictable
+	}
+
+	return &Handle{
+		Client:      client,
+		PodEvictor:  podEvictor,
+		PodInformer: podInformer,
+		Nodes:       nodes,
+		params:      validated,
+		listOpts:    listOpts,
+		evictable:   evictable,
+		sort:        sort,
+	}, nil
+}
+
+// PreFilter tells whether the strategy may evict the pod: the pod is in the namespaces and matches the
+// label selector of the strategy, and passes the Evictable constraints with the priority threshold and
+// node fit of the strategy, or the pre-filter of the strategy set WithPreFilter.
+func (h *Handle) PreFilter(pod *v1.Pod) bool {
+	if h.params.IncludedNamespaces.Len() > 0 && !h.params.IncludedNamespaces.Has(pod.Namespace) {
+		return false
+	}
+	if h.params.ExcludedNamespaces.Has(pod.Namespace) {
+		return false
+	}
+	if h.params.LabelSelector != nil && !h.params.LabelSelector.Matches(labels.Set(pod.Labels)) {
+		return false
+	}
+	return h.evictable(pod)
+}
+
+// Sort orders the eviction candidates with the sorters of the strategy
+func (h *Handle) Sort(pods []*v1.Pod) {
+	h.sort(pods)
+}
+
+// ListPods lists the pods of node in the namespaces and matching the label selector of the strategy
+// which pass filter, all of them when filter is nil, ordered with the sorters of the strategy.
+// A plugin lists the eviction candidates with a filter calling PreFilter.
+func (h *Handle) ListPods(ctx context.Context, node *v1.Node, filter func(pod *v1.Pod) bool) ([]*v1.Pod, error) {
+	pods, err := podutil.ListPodsOnANode(ctx, h.Client, node, h.listOptions(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	h.sort(pods)
+	return pods, nil
+}
+
+// ListPodsWithFieldSelector lists the pods of node like ListPods, the pods matching fieldSelector rather
+// than the ones neither succeeded nor failed, e.g. to list the failed pods.
+func (h *Handle) ListPodsWithFieldSelector(ctx context.Context, node *v1.Node, fieldSelector string, filter func(pod *v1.Pod) bool) ([]*v1.Pod, error) {
+	pods, err := podutil.ListPodsOnANodeWithFieldSelector(ctx, h.Client, node, fieldSelector, h.listOptions(filter)...)
+	if err != nil {
+		return nil, err
+	}
+	h.sort(pods)
+	return pods, nil
+}
+
+func (h *Handle) listOptions(filter func(pod *v1.Pod) bool) []func(opts *podutil.Options) {
+	if filter == nil {
+		return h.listOpts
+	}
+	return append(h.listOpts[:len(h.listOpts):len(h.listOpts)], podutil.WithFilter(filter))
+}
+
+// NewStrategy creates the function running the strategy of the plugins created by factory,
+// to be registered in the strategy registry
+func NewStrategy(name api.StrategyName, factory PluginFactory, opts ...Option) func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	return func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+		plugin, err := factory(strategy.Params)
+		if err != nil {
+			klog.ErrorS(err, "Invalid strategy parameters", "strategy", name)
+			return
+		}
+		h, err := NewHandle(ctx, client, strategy.Params, nodes, podEvictor, podInformer, opts...)
+		if err != nil {
+			klog.ErrorS(err, "Invalid strategy parameters", "strategy", name)
+			return
+		}
+
+		switch p := plugin.(type) {
+		case DeschedulePlugin:
+			// the pods of a node are evicted in order by a single worker, which keeps the evictions
+			// deterministic under the per node limits
+			workqueue.ParallelizeUntil(ctx, podEvictor.NodeConcurrency(), len(nodes), func(i int) {
+				klog.V(1).InfoS("Processing node", "node", klog.KObj(nodes[i]))
+				p.Deschedule(ctx, h, nodes[i])
+			})
+		case BalancePlugin:
+			p.Balance(ctx, h, nodes)
+		default:
+			klog.ErrorS(fmt.Errorf("plugin %T is neither a DeschedulePlugin nor a BalancePlugin", plugin), "Invalid strategy plugin", "strategy", name)
+		}
+	}
+}
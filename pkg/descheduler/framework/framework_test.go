@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// listingPlugin records the names of the eviction candidates of every node
+type listingPlugin struct {
+	listed []string
+}
+
+func (p *listingPlugin) Deschedule(ctx context.Context, h *Handle, node *v1.Node) {
+	pods, err := h.ListPods(ctx, node, h.PreFilter)
+	if err != nil {
+		return
+	}
+	for _, pod := range pods {
+		p.listed = append(p.listed, pod.Name)
+	}
+}
+
+// evictingPlugin evicts all the eviction candidates
+type evictingPlugin struct{}
+
+func (p *evictingPlugin) Deschedule(ctx context.Context, h *Handle, node *v1.Node) {
+	pods, err := h.ListPods(ctx, node, h.PreFilter)
+	if err != nil {
+		return
+	}
+	for _, pod := range pods {
+		if _, err := h.PodEvictor.EvictPod(ctx, pod, node, "Test"); err != nil {
+			break
+		}
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	newPod := func(name, namespace string, restarts int32, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.Labels = map[string]string{"app": name}
+			pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: restarts}}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	p1 := newPod("p1", "default", 1, func(pod *v1.Pod) { test.SetPodPriority(pod, 100) })
+	p2 := newPod("p2", "default", 5, func(pod *v1.Pod) { test.SetPodPriority(pod, 0) })
+	p3 := newPod("p3", "default", 3, func(pod *v1.Pod) { test.SetPodPriority(pod, 50) })
+	p4 := newPod("p4", "kube-system", 10, nil)
+	p5 := newPod("p5", "default", 20, test.SetDSOwnerRef)
+	p6 := newPod("p6", "default", 2, func(pod *v1.Pod) { test.SetPodPriority(pod, 2000000000) })
+
+	highPriority := int32(1000)
+
+	tests := []struct {
+		description string
+		params      *api.StrategyParameters
+		opts        []Option
+		expected    []string
+	}{
+		{
+			description: "evictable pods in the order they are listed",
+			expected:    []string{"p1", "p2", "p3", "p4"},
+		},
+		{
+			description: "evictable pods by default sorter",
+			opts:        []Option{WithDefaultSortBy(SortByPriority)},
+			expected:    []string{"p4", "p2", "p3", "p1"},
+		},
+		{
+			description: "sortBy overrides the default sorter",
+			params:      &api.StrategyParameters{SortBy: []string{SortByMostRestarts}},
+			opts:        []Option{WithDefaultSortBy(SortByPriority)},
+			expected:    []string{"p4", "p2", "p3", "p1"},
+		},
+		{
+			description: "pods of the included namespaces only",
+			params: &api.StrategyParameters{
+				Namespaces: &api.Namespaces{Include: []string{"default"}},
+				SortBy:     []string{SortByLeastRestarts},
+			},
+			expected: []string{"p1", "p3", "p2"},
+		},
+		{
+			description: "pods matching the label selector only",
+			params: &api.StrategyParameters{
+				LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"p2", "p3", "p5"}},
+				}},
+			},
+			expected: []string{"p2", "p3"},
+		},
+		{
+			description: "pods under the priority threshold only",
+			params:      &api.StrategyParameters{ThresholdPriority: &highPriority, SortBy: []string{SortByPriority}},
+			expected:    []string{"p4", "p2", "p3", "p1"},
+		},
+		{
+			description: "pods fitting another node only",
+			params:      &api.StrategyParameters{NodeFit: true},
+		},
+		{
+			description: "nodeFit ignored by the strategy",
+			params:      &api.StrategyParameters{NodeFit: true},
+			opts:        []Option{WithoutNodeFit()},
+			expected:    []string{"p1", "p2", "p3", "p4"},
+		},
+		{
+			description: "pre-filter instead of the evictable constraints",
+			params:      &api.StrategyParameters{Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}}},
+			opts:        []Option{WithPreFilter(func(pod *v1.Pod) bool { return pod.Name != "p1" })},
+			expected:    []string{"p2", "p3", "p5", "p6"},
+		},
+		{
+			description: "unknown sorter",
+			params:      &api.StrategyParameters{SortBy: []string{"Unknown"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewSimpleClientset(p1, p2, p3, p4, p5, p6)
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				0,
+				[]*v1.Node{node},
+				false,
+				false,
+				false,
+			)
+
+			plugin := &listingPlugin{}
+			factory := func(params *api.StrategyParameters) (Plugin, error) { return plugin, nil }
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: tc.params}
+			NewStrategy("Test", factory, tc.opts...)(ctx, fakeClient, strategy, []*v1.Node{node}, podEvictor, nil)
+
+			if !reflect.DeepEqual(plugin.listed, tc.expected) {
+				t.Errorf("Expected candidates %v, got %v", tc.expected, plugin.listed)
+			}
+		})
+	}
+}
+
+func TestNewStrategyNodeConcurrency(t *testing.T) {
+	ctx := context.Background()
+	var nodes []*v1.Node
+	var objects []runtime.Object
+	for i := 0; i < 5; i++ {
+		node := test.BuildTestNode(fmt.Sprintf("n%v", i), 2000, 3000, 10, nil)
+		nodes = append(nodes, node)
+		for j := 0; j < 4; j++ {
+			objects = append(objects, test.BuildTestPod(fmt.Sprintf("%v-p%v", node.Name, j), 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+				pod.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: int32(j)}}
+			}))
+		}
+	}
+
+	for _, concurrency := range []int{1, 3, 10} {
+		t.Run(fmt.Sprintf("concurrency %v", concurrency), func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(objects...)
+			var mu sync.Mutex
+			var evicted []string
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					mu.Lock()
+					defer mu.Unlock()
+					evicted = append(evicted, action.(core.CreateAction).GetObject().(*policyv1.Eviction).Name)
+					return true, nil, nil
+				}
+				return false, nil, nil
+			})
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				2,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+				evictions.WithNodeConcurrency(concurrency),
+			)
+
+			factory := func(params *api.StrategyParameters) (Plugin, error) { return &evictingPlugin{}, nil }
+			strategy := api.DeschedulerStrategy{Enabled: true, Params: &api.StrategyParameters{SortBy: []string{SortByMostRestarts}}}
+			NewStrategy("Test", factory)(ctx, fakeClient, strategy, nodes, podEvictor, nil)
+
+			// the pods with the most restarts of every node are evicted, whatever the order of the nodes
+			var expected []string
+			for _, node := range nodes {
+				expected = append(expected, node.Name+"-p2", node.Name+"-p3")
+			}
+			sort.Strings(evicted)
+			if !reflect.DeepEqual(evicted, expected) {
+				t.Errorf("Expected evicted pods %v, got %v", expected, evicted)
+			}
+		})
+	}
+}
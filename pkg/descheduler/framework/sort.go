@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// SortByPriority evicts the pods of lower priority first, and the pods of lower QoS class among the pods
+	// of the same priority
+	SortByPriority = "Priority"
+	// SortByLeastRestarts evicts the pods whose containers restarted the least first
+	SortByLeastRestarts = "LeastRestarts"
+	// SortByMostRestarts evicts the pods whose containers restarted the most first
+	SortByMostRestarts = "MostRestarts"
+	// SortByOldest evicts the oldest pods first
+	SortByOldest = "Oldest"
+)
+
+// CompareFunc orders two eviction candidates. It returns a negative number when a is to be evicted
+// before b, a positive number when b is to be evicted before a, and 0 when it does not tell them apart.
+type CompareFunc func(a, b *v1.Pod) int
+
+var (
+	sortersMu sync.RWMutex
+	sorters   = map[string]CompareFunc{
+		SortByPriority:      comparePriority,
+		SortByLeastRestarts: compareRestarts,
+		SortByMostRestarts:  func(a, b *v1.Pod) int { return compareRestarts(b, a) },
+		SortByOldest:        compareCreation,
+	}
+)
+
+// RegisterSorter adds a sorter policies can refer to by name in sortBy. It panics when a sorter
+// with the same name is registered already.
+func RegisterSorter(name string, compare CompareFunc) {
+	if name == "" || compare == nil {
+		panic(fmt.Sprintf("sorter %q registered without a name or compare function", name))
+	}
+	sortersMu.Lock()
+	defer sortersMu.Unlock()
+	if _, ok := sorters[name]; ok {
+		panic(fmt.Sprintf("sorter %q registered twice", name))
+	}
+	sorters[name] = compare
+}
+
+// SorterNames gives the names of the registered sorters, in alphabetical order
+func SorterNames() []string {
+	sortersMu.RLock()
+	defer sortersMu.RUnlock()
+	names := make([]string, 0, len(sorters))
+	for name := range sorters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewSorter chains the sorters with the given names, the pods the first sorter does not tell apart
+// being ordered by the second one and so on. The sorter keeps the order of the pods none of the
+// sorters tell apart.
+func NewSorter(names ...string) (func(pods []*v1.Pod), error) {
+	sortersMu.RLock()
+	defer sortersMu.RUnlock()
+	compares := make([]CompareFunc, 0, len(names))
+	for _, name := range names {
+		compare, ok := sorters[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sorter %q", name)
+		}
+		compares = append(compares, compare)
+	}
+	return func(pods []*v1.Pod) {
+		if len(compares) == 0 {
+			return
+		}
+		sort.SliceStable(pods, func(i, j int) bool {
+			for _, compare := range compares {
+				if c := compare(pods[i], pods[j]); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}, nil
+}
+
+// comparePriority orders the pods by priority, the pods without priority first, then by QoS class
+func comparePriority(a, b *v1.Pod) int {
+	switch {
+	case a.Spec.Priority == nil && b.Spec.Priority != nil:
+		return -1
+	case a.Spec.Priority != nil && b.Spec.Priority == nil:
+		return 1
+	case a.Spec.Priority != nil && *a.Spec.Priority != *b.Spec.Priority:
+		if *a.Spec.Priority < *b.Spec.Priority {
+			return -1
+		}
+		return 1
+	}
+	return qosRank(a) - qosRank(b)
+}
+
+func qosRank(pod *v1.Pod) int {
+	switch utils.GetPodQOS(pod) {
+	case v1.PodQOSBestEffort:
+		return 0
+	case v1.PodQOSBurstable:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// compareRestarts orders the pods by the restarts of their containers, init containers included
+func compareRestarts(a, b *v1.Pod) int {
+	return int(podRestarts(a)) - int(podRestarts(b))
+}
+
+func podRestarts(pod *v1.Pod) int32 {
+	var restarts int32
+	for _, cs := range pod.Status.InitContainerStatuses {
+		restarts += cs.RestartCount
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		restarts += cs.RestartCount
+	}
+	return restarts
+}
+
+// compareCreation orders the pods by creation time
+func compareCreation(a, b *v1.Pod) int {
+	ta, tb := a.CreationTimestamp, b.CreationTimestamp
+	switch {
+	case ta.Equal(&tb):
+		return 0
+	case ta.Before(&tb):
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNewSorter(t *testing.T) {
+	now := time.Now()
+	newPod := func(name string, age time.Duration, restarts int32, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+			pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{RestartCount: restarts}}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	pods := []*v1.Pod{
+		newPod("guaranteed", time.Hour, 2, func(pod *v1.Pod) {
+			test.SetPodPriority(pod, 10)
+			test.MakeGuaranteedPod(pod)
+		}),
+		newPod("best-effort", 3*time.Hour, 0, func(pod *v1.Pod) {
+			test.SetPodPriority(pod, 10)
+			test.MakeBestEffortPod(pod)
+		}),
+		newPod("no-priority", 2*time.Hour, 2, nil),
+		newPod("low-priority", time.Hour, 5, func(pod *v1.Pod) { test.SetPodPriority(pod, 1) }),
+	}
+
+	tests := []struct {
+		description string
+		sortBy      []string
+		expected    []string
+		expectErr   bool
+	}{
+		{
+			description: "no sorter",
+			expected:    []string{"guaranteed", "best-effort", "no-priority", "low-priority"},
+		},
+		{
+			description: "priority then QoS class",
+			sortBy:      []string{SortByPriority},
+			expected:    []string{"no-priority", "low-priority", "best-effort", "guaranteed"},
+		},
+		{
+			description: "least restarts, ties kept in order",
+			sortBy:      []string{SortByLeastRestarts},
+			expected:    []string{"best-effort", "guaranteed", "no-priority", "low-priority"},
+		},
+		{
+			description: "most restarts then oldest",
+			sortBy:      []string{SortByMostRestarts, SortByOldest},
+			expected:    []string{"low-priority", "no-priority", "guaranteed", "best-effort"},
+		},
+		{
+			description: "unknown sorter",
+			sortBy:      []string{SortByOldest, "Unknown"},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			sort, err := NewSorter(tc.sortBy...)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error for sorters %v", tc.sortBy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			sorted := append([]*v1.Pod(nil), pods...)
+			sort(sorted)
+			var names []string
+			for _, pod := range sorted {
+				names = append(names, pod.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("Expected pods in order %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestRegisterSorter(t *testing.T) {
+	RegisterSorter("Name", func(a, b *v1.Pod) int {
+		switch {
+		case a.Name < b.Name:
+			return -1
+		case a.Name > b.Name:
+			return 1
+		}
+		return 0
+	})
+	if names := SorterNames(); !reflect.DeepEqual(names, []string{"LeastRestarts", "MostRestarts", "Name", "Oldest", "Priority"}) {
+		t.Errorf("Unexpected sorters %v", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected registering a sorter twice to panic")
+		}
+	}()
+	RegisterSorter(SortByPriority, compareCreation)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health tracks the state the liveness and readiness checks of the descheduler are based on
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apiserver/pkg/server/healthz"
+)
+
+// State is the health state of the descheduler. The zero value is not ready.
+type State struct {
+	mu sync.RWMutex
+	// livenessTimeout is how long the descheduling loop may go without completing a cycle, 0 disables the check
+	livenessTimeout   time.Duration
+	evictionSupported bool
+	informersStarted  bool
+	informersSynced   bool
+	// running is set while the descheduling loop runs, a replica waiting for the leader lease stays alive
+	running       bool
+	lastCompleted time.Time
+	now           func() time.Time
+}
+
+// NewState creates the health state of a descheduler whose loop has to complete a cycle at least once every livenessTimeout
+func NewState(livenessTimeout time.Duration) *State {
+	return &State{livenessTimeout: livenessTimeout, now: time.Now}
+}
+
+// SetEvictionSupported records the eviction API was found to be served
+func (s *State) SetEvictionSupported() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictionSupported = true
+}
+
+// SetInformersStarted records the informers of the strategies were started, the descheduler is not ready until they synced
+func (s *State) SetInformersStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.informersStarted = true
+	s.informersSynced = false
+}
+
+// SetInformersSynced records the caches of the informers of the strategies synced
+func (s *State) SetInformersSynced() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.informersSynced = true
+}
+
+// LoopStarted records the descheduling loop started, the liveness timeout counting from now
+func (s *State) LoopStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = true
+	s.lastCompleted = s.now()
+}
+
+// LoopStopped records the descheduling loop returned, e.g. after the leader lease was lost
+func (s *State) LoopStopped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+}
+
+// CycleCompleted records a descheduling cycle completed
+func (s *State) CycleCompleted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCompleted = s.now()
+}
+
+// Ready fails until the eviction API was found and the caches of the informers started synced
+func (s *State) Ready() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.evictionSupported {
+		return fmt.Errorf("eviction support not checked yet")
+	}
+	if s.informersStarted && !s.informersSynced {
+		return fmt.Errorf("informer caches not synced yet")
+	}
+	return nil
+}
+
+// Alive fails when the running descheduling loop did not complete a cycle within the liveness timeout
+func (s *State) Alive() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.running || s.livenessTimeout <= 0 {
+		return nil
+	}
+	if since := s.now().Sub(s.lastCompleted); since > s.livenessTimeout {
+		return fmt.Errorf("no descheduling cycle completed for %v, more than %v", since.Round(time.Second), s.livenessTimeout)
+	}
+	return nil
+}
+
+// ReadinessCheck is the check of the /readyz endpoint
+func (s *State) ReadinessCheck() healthz.HealthChecker {
+	return healthz.NamedCheck("descheduler-ready", func(_ *http.Request) error { return s.Ready() })
+}
+
+// LivenessCheck is the check of the /healthz endpoint
+func (s *State) LivenessCheck() healthz.HealthChecker {
+	return healthz.NamedCheck("descheduler-cycles", func(_ *http.Request) error { return s.Alive() })
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apiserver/pkg/server/healthz"
+	"k8s.io/apiserver/pkg/server/mux"
+)
+
+func TestReady(t *testing.T) {
+	state := NewState(time.Minute)
+	if err := state.Ready(); err == nil {
+		t.Errorf("Expected not to be ready before the eviction support was checked")
+	}
+	state.SetEvictionSupported()
+	if err := state.Ready(); err != nil {
+		t.Errorf("Expected a replica waiting for the lease to be ready, got %v", err)
+	}
+	state.SetInformersStarted()
+	if err := state.Ready(); err == nil {
+		t.Errorf("Expected not to be ready before the informers synced")
+	}
+	state.SetInformersSynced()
+	if err := state.Ready(); err != nil {
+		t.Errorf("Expected to be ready, got %v", err)
+	}
+}
+
+func TestAlive(t *testing.T) {
+	now := time.Unix(0, 0)
+	state := NewState(3 * time.Minute)
+	state.now = func() time.Time { return now }
+
+	now = now.Add(time.Hour)
+	if err := state.Alive(); err != nil {
+		t.Errorf("Expected to be alive before the loop started, got %v", err)
+	}
+
+	state.LoopStarted()
+	now = now.Add(2 * time.Minute)
+	if err := state.Alive(); err != nil {
+		t.Errorf("Expected to be alive within the timeout, got %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := state.Alive(); err == nil {
+		t.Errorf("Expected not to be alive without a cycle completed within the timeout")
+	}
+	state.CycleCompleted()
+	if err := state.Alive(); err != nil {
+		t.Errorf("Expected to be alive after a cycle completed, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	state.LoopStopped()
+	if err := state.Alive(); err != nil {
+		t.Errorf("Expected to be alive once the loop stopped, got %v", err)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	state := NewState(0)
+	pathRecorderMux := mux.NewPathRecorderMux("test")
+	healthz.InstallHandler(pathRecorderMux, state.LivenessCheck())
+	healthz.InstallReadyzHandler(pathRecorderMux, state.ReadinessCheck())
+
+	for _, tc := range []struct {
+		path string
+		code int
+	}{
+		{"/healthz", 200},
+		{"/readyz", 500},
+	} {
+		recorder := httptest.NewRecorder()
+		pathRecorderMux.ServeHTTP(recorder, httptest.NewRequest("GET", tc.path, nil))
+		if recorder.Code != tc.code {
+			t.Errorf("Expected %s to answer %d, got %d: %s", tc.path, tc.code, recorder.Code, recorder.Body.String())
+		}
+	}
+}
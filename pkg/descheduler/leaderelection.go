@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	componentbaseconfig "k8s.io/component-base/config"
+	"k8s.io/klog/v2"
+)
+
+// NewLeaderElection starts the leader election loop and invokes run once the lease is acquired.
+// The context passed to run is cancelled as soon as the lease is lost or ctx is cancelled, after
+// which NewLeaderElection returns once run returned.
+func NewLeaderElection(
+	run func(ctx context.Context) error,
+	client clientset.Interface,
+	leaderElectionConfig *componentbaseconfig.LeaderElectionConfiguration,
+	ctx context.Context,
+) error {
+	id, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("unable to get hostname: %v", err)
+	}
+	// add a uniquifier so that two processes on the same host don't accidentally both become active
+	id = id + "_" + string(uuid.NewUUID())
+	klog.V(3).InfoS("Assigned unique lease holder id", "id", id, "type", leaderElectionConfig.ResourceLock)
+
+	lock, err := resourcelock.New(
+		leaderElectionConfig.ResourceLock,
+		leaderElectionConfig.ResourceNamespace,
+		leaderElectionConfig.ResourceName,
+		client.CoreV1(),
+		client.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create leader election lock: %v", err)
+	}
+
+	// the elector runs OnStartedLeading in its own goroutine and does not wait for it to return. A run
+	// starting after the elector returned gets a cancelled context and does not start a cycle.
+	var mu sync.Mutex
+	var done chan struct{}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaderElectionConfig.LeaseDuration.Duration,
+		RenewDeadline:   leaderElectionConfig.RenewDeadline.Duration,
+		RetryPeriod:     leaderElectionConfig.RetryPeriod.Duration,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				mu.Lock()
+				runDone := make(chan struct{})
+				done = runDone
+				mu.Unlock()
+				defer close(runDone)
+				klog.V(1).InfoS("Started leading")
+				if err := run(ctx); err != nil {
+					klog.ErrorS(err, "Descheduler strategies failed")
+				}
+			},
+			OnStoppedLeading: func() {
+				klog.V(1).InfoS("Leader lost")
+			},
+			OnNewLeader: func(identity string) {
+				if identity == id {
+					return
+				}
+				klog.V(1).InfoS("New leader elected", "identity", identity)
+			},
+		},
+		Name: "descheduler",
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create leader elector: %v", err)
+	}
+
+	elector.Run(ctx)
+	mu.Lock()
+	runDone := done
+	mu.Unlock()
+	if runDone != nil {
+		<-runDone
+	}
+	return nil
+}
@@ -18,20 +18,29 @@ package pod
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
+// nodeNameIndex is the name of the pod informer index keyed by spec.nodeName
+const nodeNameIndex = "nodeName"
+
 type Options struct {
 	filter             func(pod *v1.Pod) bool
 	includedNamespaces []string
 	excludedNamespaces []string
 	labelSelector      *metav1.LabelSelector
+	podInformer        coreinformers.PodInformer
 }
 
 // WithFilter sets a pod filter.
@@ -63,6 +72,32 @@ func WithLabelSelector(labelSelector *metav1.LabelSelector) func(opts *Options)
 	}
 }
 
+// WithPodInformer lists pods from the informer cache instead of the apiserver.
+// Pods are listed through the client as long as the informer has not synced yet.
+// Pods returned from the cache are shared and must not be modified.
+func WithPodInformer(podInformer coreinformers.PodInformer) func(opts *Options) {
+	return func(opts *Options) {
+		opts.podInformer = podInformer
+	}
+}
+
+// AddNodeNameIndexer indexes the pods in the informer cache by the name of the node they are assigned to.
+// It has to be called before the informer is started.
+func AddNodeNameIndexer(podInformer coreinformers.PodInformer) error {
+	return podInformer.Informer().AddIndexers(cache.Indexers{
+		nodeNameIndex: func(obj interface{}) ([]string, error) {
+			pod, ok := obj.(*v1.Pod)
+			if !ok {
+				return []string{}, fmt.Errorf("expected *v1.Pod, got %T", obj)
+			}
+			if len(pod.Spec.NodeName) == 0 {
+				return []string{}, nil
+			}
+			return []string{pod.Spec.NodeName}, nil
+		},
+	})
+}
+
 // ListPodsOnANode lists all of the pods on a node
 // It also accepts an optional "filter" function which can be used to further limit the pods that are returned.
 // (Usually this is podEvictor.Evictable().IsEvictable, in order to only list the evictable pods on a node, but can
@@ -91,6 +126,10 @@ func ListPodsOnANodeWithFieldSelector(
 		opt(options)
 	}
 
+	if options.podInformer != nil && options.podInformer.Informer().HasSynced() {
+		return listPodsFromInformer(options.podInformer, node.Name, fieldSelectorString, options)
+	}
+
 	pods := make([]*v1.Pod, 0)
 
 	labelSelectorString := ""
@@ -185,6 +224,10 @@ func ListPodsWithFieldSelector(
 		opt(options)
 	}
 
+	if options.podInformer != nil && options.podInformer.Informer().HasSynced() {
+		return listPodsFromInformer(options.podInformer, "", fieldSelectorString, options)
+	}
+
 	pods := make([]*v1.Pod, 0)
 
 	labelSelectorString := ""
@@ -253,6 +296,79 @@ func ListPodsWithFieldSelector(
 	return pods, nil
 }
 
+// listPodsFromInformer lists pods from the informer cache, optionally limited to the pods assigned to nodeName.
+// Field selectors are not supported by listers, so they are evaluated on the client side.
+func listPodsFromInformer(podInformer coreinformers.PodInformer, nodeName, fieldSelectorString string, options *Options) ([]*v1.Pod, error) {
+	labelSelector := labels.Everything()
+	if options.labelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(options.labelSelector)
+		if err != nil {
+			return []*v1.Pod{}, err
+		}
+		labelSelector = selector
+	}
+
+	if len(options.includedNamespaces) == 0 {
+		for _, namespace := range options.excludedNamespaces {
+			fieldSelectorString += ",metadata.namespace!=" + namespace
+		}
+	}
+	fieldSelector, err := fields.ParseSelector(fieldSelectorString)
+	if err != nil {
+		return []*v1.Pod{}, err
+	}
+
+	var cached []*v1.Pod
+	if len(nodeName) > 0 {
+		objs, err := podInformer.Informer().GetIndexer().ByIndex(nodeNameIndex, nodeName)
+		if err != nil {
+			// the index was not registered, look through all the pods instead
+			cached, err = podInformer.Lister().List(labels.Everything())
+			if err != nil {
+				return []*v1.Pod{}, err
+			}
+		}
+		for _, obj := range objs {
+			if pod, ok := obj.(*v1.Pod); ok {
+				cached = append(cached, pod)
+			}
+		}
+	} else {
+		cached, err = podInformer.Lister().List(labels.Everything())
+		if err != nil {
+			return []*v1.Pod{}, err
+		}
+	}
+
+	includedNamespaces := sets.NewString(options.includedNamespaces...)
+	pods := make([]*v1.Pod, 0)
+	for _, pod := range cached {
+		if len(nodeName) > 0 && pod.Spec.NodeName != nodeName {
+			continue
+		}
+		if includedNamespaces.Len() > 0 && !includedNamespaces.Has(pod.Namespace) {
+			continue
+		}
+		if !fieldSelector.Matches(podFields(pod)) || !labelSelector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if options.filter != nil && !options.filter(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// podFields returns the pod fields the descheduler filters on through field selectors
+func podFields(pod *v1.Pod) fields.Set {
+	return fields.Set{
+		"metadata.name":      pod.Name,
+		"metadata.namespace": pod.Namespace,
+		"spec.nodeName":      pod.Spec.NodeName,
+		"status.phase":       string(pod.Status.Phase),
+	}
+}
 
 // OwnerRef returns the ownerRefList for the pod.
 func OwnerRef(pod *v1.Pod) []metav1.OwnerReference {
@@ -293,4 +409,4 @@ func SortPodsBasedOnPriorityLowToHigh(pods []*v1.Pod) {
 		}
 		return *pods[i].Spec.Priority < *pods[j].Spec.Priority
 	})
-}
\ No newline at end of file
+}
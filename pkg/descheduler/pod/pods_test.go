@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/test"
@@ -103,6 +105,108 @@ func TestListPodsOnANode(t *testing.T) {
 	}
 }
 
+func TestListPodsWithPodInformer(t *testing.T) {
+	p1 := test.BuildTestPod("p1", 100, 0, "n1", nil)
+	p2 := test.BuildTestPod("p2", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"foo": "bar"}
+	})
+	p3 := test.BuildTestPod("p3", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Namespace = "kube-system"
+	})
+	p4 := test.BuildTestPod("p4", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Status.Phase = v1.PodFailed
+	})
+	p5 := test.BuildTestPod("p5", 100, 0, "n2", nil)
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+
+	testCases := []struct {
+		name         string
+		nodeName     string
+		opts         []func(opts *Options)
+		expectedPods []string
+	}{
+		{
+			name:         "pods on a node",
+			nodeName:     "n1",
+			expectedPods: []string{"p1", "p2", "p3"},
+		},
+		{
+			name:         "pods on a node in included namespaces",
+			nodeName:     "n1",
+			opts:         []func(opts *Options){WithNamespaces([]string{"kube-system"})},
+			expectedPods: []string{"p3"},
+		},
+		{
+			name:         "pods on a node without excluded namespaces",
+			nodeName:     "n1",
+			opts:         []func(opts *Options){WithoutNamespaces([]string{"kube-system"})},
+			expectedPods: []string{"p1", "p2"},
+		},
+		{
+			name:     "pods on a node with label selector",
+			nodeName: "n1",
+			opts: []func(opts *Options){WithLabelSelector(&metav1.LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+			})},
+			expectedPods: []string{"p2"},
+		},
+		{
+			name:     "pods on a node with filter",
+			nodeName: "n1",
+			opts: []func(opts *Options){WithFilter(func(pod *v1.Pod) bool {
+				return pod.Name != "p1"
+			})},
+			expectedPods: []string{"p2", "p3"},
+		},
+		{
+			name:         "pods on all nodes",
+			expectedPods: []string{"p1", "p2", "p3", "p5"},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fakeClient := fake.NewSimpleClientset(p1, p2, p3, p4, p5)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods()
+	if err := AddNodeNameIndexer(podInformer); err != nil {
+		t.Fatalf("Unable to add node name indexer: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// once the cache is synced, no pods are expected to be listed through the client
+	fakeClient.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("unexpected list call: %v", action)
+	})
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			opts := append([]func(opts *Options){WithPodInformer(podInformer)}, testCase.opts...)
+			var pods []*v1.Pod
+			var err error
+			if len(testCase.nodeName) > 0 {
+				node.Name = testCase.nodeName
+				pods, err = ListPodsOnANode(ctx, fakeClient, node, opts...)
+			} else {
+				pods, err = ListPods(ctx, fakeClient, opts...)
+			}
+			if err != nil {
+				t.Fatalf("Unable to list pods: %v", err)
+			}
+			var podNames []string
+			for _, pod := range pods {
+				podNames = append(podNames, pod.Name)
+			}
+			sort.Strings(podNames)
+			if !reflect.DeepEqual(podNames, testCase.expectedPods) {
+				t.Errorf("Expected pods %v, got %v", testCase.expectedPods, podNames)
+			}
+		})
+	}
+}
+
 func TestSortPodsBasedOnPriorityLowToHigh(t *testing.T) {
 	n1 := test.BuildTestNode("n1", 4000, 3000, 9, nil)
 
@@ -20,12 +20,15 @@ import (
 	"fmt"
 	"io/ioutil"
 
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/validation"
 	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
 )
 
 func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
@@ -39,17 +42,46 @@ func LoadPolicyConfig(policyConfigFile string) (*api.DeschedulerPolicy, error) {
 		return nil, fmt.Errorf("failed to read policy config file %q: %+v", policyConfigFile, err)
 	}
 
-	versionedPolicy := &v1alpha1.DeschedulerPolicy{}
+	internalPolicy, err := decodePolicy(policyConfigFile, policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePolicy(internalPolicy); err != nil {
+		return nil, fmt.Errorf("invalid descheduler's policy config %q: %v", policyConfigFile, err)
+	}
 
-	decoder := scheme.Codecs.UniversalDecoder(v1alpha1.SchemeGroupVersion)
-	if err := runtime.DecodeInto(decoder, policy, versionedPolicy); err != nil {
+	return internalPolicy, nil
+}
+
+// decodePolicy decodes the content of the policy file into the internal policy version.
+// Any version of the policy is accepted, the policy without apiVersion being read as v1alpha1.
+// The enabled strategies configured without parameters get their default ones.
+func decodePolicy(policyConfigFile string, policy []byte) (*api.DeschedulerPolicy, error) {
+	defaultGVK := v1alpha1.SchemeGroupVersion.WithKind("DeschedulerPolicy")
+	obj, _, err := scheme.Codecs.UniversalDecoder().Decode(policy, &defaultGVK, nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, err)
 	}
 
-	internalPolicy := &api.DeschedulerPolicy{}
-	if err := scheme.Scheme.Convert(versionedPolicy, internalPolicy, nil); err != nil {
-		return nil, fmt.Errorf("failed converting versioned policy to internal policy version: %v", err)
+	internalPolicy, ok := obj.(*api.DeschedulerPolicy)
+	if !ok {
+		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: unexpected %T", policyConfigFile, obj)
 	}
+	registry.SetDefaultParams(internalPolicy)
 
 	return internalPolicy, nil
 }
+
+// EncodePolicy encodes the policy as YAML in the given version of the policy API
+func EncodePolicy(deschedulerPolicy *api.DeschedulerPolicy, gv schema.GroupVersion) ([]byte, error) {
+	versionedPolicy, err := scheme.Scheme.ConvertToVersion(deschedulerPolicy.DeepCopy(), gv)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting internal policy to version %v: %v", gv, err)
+	}
+	return yaml.Marshal(versionedPolicy)
+}
+
+// ValidatePolicy checks the settings of the policy and the parameters of the enabled strategies
+func ValidatePolicy(deschedulerPolicy *api.DeschedulerPolicy) error {
+	return validation.ValidateDeschedulerPolicy(deschedulerPolicy).ToAggregate()
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+)
+
+func TestEncodePolicy(t *testing.T) {
+	policy, err := LoadPolicyConfig(filepath.Join("..", "..", "examples", "policy.yaml"))
+	if err != nil {
+		t.Fatalf("Unable to load policy: %v", err)
+	}
+
+	for _, gv := range []schema.GroupVersion{v1alpha1.SchemeGroupVersion, v1alpha2.SchemeGroupVersion} {
+		data, err := EncodePolicy(policy, gv)
+		if err != nil {
+			t.Fatalf("Unable to encode policy in %v: %v", gv, err)
+		}
+		if bytes.Contains(data, []byte("metadata:")) || bytes.Contains(data, []byte("status:")) {
+			t.Errorf("Expected the policy read from a file to be encoded without metadata and status, got\n%s", data)
+		}
+		file := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := ioutil.WriteFile(file, data, 0600); err != nil {
+			t.Fatalf("Unable to write policy: %v", err)
+		}
+		decoded, err := LoadPolicyConfig(file)
+		if err != nil {
+			t.Fatalf("Unable to load policy encoded in %v: %v\n%s", gv, err, data)
+		}
+		if !reflect.DeepEqual(decoded, policy) {
+			t.Errorf("Expected the policy encoded in %v to be loaded unchanged, got %+v", gv, decoded)
+		}
+	}
+}
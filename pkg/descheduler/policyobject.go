@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/registry"
+)
+
+// LoadPolicyObject reads the policy from the spec of the cluster scoped DeschedulerPolicy object
+func LoadPolicyObject(ctx context.Context, client dynamic.Interface, name string) (*api.DeschedulerPolicy, error) {
+	obj, err := client.Resource(v1alpha1.DeschedulerPoliciesResource).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get DeschedulerPolicy %q: %v", name, err)
+	}
+
+	object := &v1alpha1.DeschedulerPolicyObject{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), object); err != nil {
+		return nil, fmt.Errorf("failed decoding DeschedulerPolicy %q: %v", name, err)
+	}
+	versionedPolicy := &object.Spec
+	scheme.Scheme.Default(versionedPolicy)
+
+	internalPolicy := &api.DeschedulerPolicy{}
+	if err := scheme.Scheme.Convert(versionedPolicy, internalPolicy, nil); err != nil {
+		return nil, fmt.Errorf("failed converting versioned policy to internal policy version: %v", err)
+	}
+	registry.SetDefaultParams(internalPolicy)
+
+	return internalPolicy, nil
+}
+
+// updatePolicyStatus replaces the status of the DeschedulerPolicy object
+func updatePolicyStatus(ctx context.Context, client dynamic.Interface, name string, status *api.DeschedulerPolicyStatus) error {
+	versionedStatus := &v1alpha1.DeschedulerPolicyStatus{}
+	if err := scheme.Scheme.Convert(status, versionedStatus, nil); err != nil {
+		return fmt.Errorf("failed converting internal policy status to versioned policy status: %v", err)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(versionedStatus)
+	if err != nil {
+		return err
+	}
+	// the omitted fields are set to null for the merge patch to clear them
+	for _, field := range []string{"lastRunTime", "strategies", "validationErrors"} {
+		if _, ok := content[field]; !ok {
+			content[field] = nil
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{"status": content})
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Resource(v1alpha1.DeschedulerPoliciesResource).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+		return fmt.Errorf("unable to update the status of DeschedulerPolicy %q: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+)
+
+func TestPolicyObject(t *testing.T) {
+	ctx := context.Background()
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v1alpha1.CustomResourceGroupName + "/v1alpha1",
+		"kind":       "DeschedulerPolicy",
+		"metadata": map[string]interface{}{
+			"name": "default",
+		},
+		"spec": map[string]interface{}{
+			"maxNoOfPodsToEvictPerNode": int64(5),
+			"strategies": map[string]interface{}{
+				"PodLifeTime": map[string]interface{}{
+					"enabled": true,
+					"params": map[string]interface{}{
+						"podLifeTime": map[string]interface{}{"maxPodLifeTimeSeconds": int64(86400)},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"validationErrors": []interface{}{"evictionGracePeriodSeconds must not be negative, got -1"},
+		},
+	}}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		v1alpha1.DeschedulerPoliciesResource: "DeschedulerPolicyList",
+	}, policy)
+
+	internalPolicy, err := LoadPolicyObject(ctx, client, "default")
+	if err != nil {
+		t.Fatalf("Unable to load policy: %v", err)
+	}
+	if *internalPolicy.MaxNoOfPodsToEvictPerNode != 5 ||
+		!internalPolicy.Strategies["PodLifeTime"].Enabled || *internalPolicy.Strategies["PodLifeTime"].Params.PodLifeTime.MaxPodLifeTimeSeconds != 86400 {
+		t.Errorf("Unexpected policy %+v", internalPolicy)
+	}
+
+	lastRunTime := metav1.Unix(1627812000, 0)
+	status := &api.DeschedulerPolicyStatus{
+		LastRunTime:  &lastRunTime,
+		TotalEvicted: 3,
+		Strategies:   []api.StrategyStatus{{Name: "PodLifeTime", Evicted: 3}},
+	}
+	if err := updatePolicyStatus(ctx, client, "default", status); err != nil {
+		t.Fatalf("Unable to update policy status: %v", err)
+	}
+
+	updated, err := client.Resource(v1alpha1.DeschedulerPoliciesResource).Get(ctx, "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get policy: %v", err)
+	}
+	expected := map[string]interface{}{
+		"lastRunTime":  lastRunTime.UTC().Format("2006-01-02T15:04:05Z"),
+		"totalEvicted": int64(3),
+		"strategies":   []interface{}{map[string]interface{}{"name": "PodLifeTime", "evicted": int64(3)}},
+	}
+	if got := updated.Object["status"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected status %v, got %v", expected, got)
+	}
+}
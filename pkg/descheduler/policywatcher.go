@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+const (
+	// policyReloadPeriod is how often the policy file is checked for changes. The files of a mounted
+	// ConfigMap are updated by the kubelet, so changes of the ConfigMap are picked up as well.
+	policyReloadPeriod = 10 * time.Second
+
+	// EventReasonPolicyReloadFailed is the reason of the events recorded for invalid policy updates
+	EventReasonPolicyReloadFailed = "PolicyReloadFailed"
+)
+
+// policyWatcher reloads the policy file whenever it changes. An invalid policy is
+// reported and ignored, the last valid one being kept.
+type policyWatcher struct {
+	file          string
+	eventRecorder record.EventRecorder
+	// eventObject is the object the events are recorded on, if any
+	eventObject *v1.ObjectReference
+	// policy holds the last valid *api.DeschedulerPolicy
+	policy atomic.Value
+	// contents is the content of the file last loaded, valid or not
+	contents []byte
+	// readErr is the error the file failed to be read with last, reported only once like invalid contents
+	readErr string
+}
+
+// newPolicyWatcher creates a watcher of the policy file, policy being the one loaded from it already
+func newPolicyWatcher(file string, policy *api.DeschedulerPolicy, eventRecorder record.EventRecorder) *policyWatcher {
+	w := &policyWatcher{
+		file:          file,
+		eventRecorder: eventRecorder,
+		eventObject:   deschedulerPodReference(),
+	}
+	w.policy.Store(policy)
+	// the policy was loaded from the current content of the file
+	w.contents, _ = ioutil.ReadFile(file)
+	return w
+}
+
+// Policy gives the last valid policy. The policy must not be modified.
+func (w *policyWatcher) Policy() *api.DeschedulerPolicy {
+	return w.policy.Load().(*api.DeschedulerPolicy)
+}
+
+// Run checks the policy file for changes every period until ctx is done
+func (w *policyWatcher) Run(ctx context.Context, period time.Duration) {
+	wait.Until(w.reload, period, ctx.Done())
+}
+
+// reload loads and validates the policy file when its content changed
+func (w *policyWatcher) reload() {
+	contents, err := ioutil.ReadFile(w.file)
+	if err != nil {
+		err = fmt.Errorf("failed to read policy config file %q: %v", w.file, err)
+		if err.Error() != w.readErr {
+			w.readErr = err.Error()
+			w.reloadFailed(err)
+		}
+		return
+	}
+	w.readErr = ""
+	if bytes.Equal(contents, w.contents) {
+		return
+	}
+	w.contents = contents
+
+	policy, err := decodePolicy(w.file, contents)
+	if err == nil {
+		err = ValidatePolicy(policy)
+	}
+	if err != nil {
+		w.reloadFailed(err)
+		return
+	}
+
+	w.policy.Store(policy)
+	metrics.PolicyReloads.With(map[string]string{"result": "success"}).Inc()
+	klog.V(1).InfoS("Reloaded policy", "file", w.file)
+}
+
+func (w *policyWatcher) reloadFailed(err error) {
+	klog.ErrorS(err, "Unable to reload policy, keeping the previous one", "file", w.file)
+	metrics.PolicyReloads.With(map[string]string{"result": "error"}).Inc()
+	if w.eventRecorder != nil && w.eventObject != nil {
+		w.eventRecorder.Eventf(w.eventObject, v1.EventTypeWarning, EventReasonPolicyReloadFailed, "Unable to reload policy from %s, keeping the previous one: %v", w.file, err)
+	}
+}
+
+// deschedulerPodReference refers to the pod the descheduler runs in, as exposed through
+// the POD_NAME and POD_NAMESPACE environment variables, if set.
+func deschedulerPodReference() *v1.ObjectReference {
+	name, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if name == "" || namespace == "" {
+		return nil
+	}
+	return &v1.ObjectReference{Kind: "Pod", APIVersion: "v1", Name: name, Namespace: namespace}
+}
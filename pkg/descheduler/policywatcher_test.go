@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestPolicyWatcherReload(t *testing.T) {
+	for name, value := range map[string]string{"POD_NAME": "descheduler", "POD_NAMESPACE": "kube-system"} {
+		os.Setenv(name, value)
+		defer os.Unsetenv(name)
+	}
+
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy := func(policy string) {
+		if err := ioutil.WriteFile(file, []byte(policy), 0600); err != nil {
+			t.Fatalf("Unable to write policy: %v", err)
+		}
+	}
+
+	writePolicy(`
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 1
+`)
+	initial, err := LoadPolicyConfig(file)
+	if err != nil {
+		t.Fatalf("Unable to load policy: %v", err)
+	}
+	recorder := record.NewFakeRecorder(10)
+	watcher := newPolicyWatcher(file, initial, recorder)
+
+	watcher.reload()
+	if watcher.Policy() != initial {
+		t.Errorf("Expected the policy not to be reloaded when the file did not change")
+	}
+
+	writePolicy(`
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 2
+`)
+	watcher.reload()
+	reloaded := watcher.Policy()
+	if reloaded == initial || reloaded.MaxNoOfPodsToEvictPerNode == nil || *reloaded.MaxNoOfPodsToEvictPerNode != 2 {
+		t.Errorf("Expected the updated policy to be reloaded, got %+v", reloaded)
+	}
+
+	for _, invalid := range []string{
+		`not a policy`,
+		`
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+evictionGracePeriodSeconds: -1
+`,
+	} {
+		writePolicy(invalid)
+		watcher.reload()
+		if watcher.Policy() != reloaded {
+			t.Errorf("Expected the last valid policy to be kept on invalid update %q", invalid)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, v1.EventTypeWarning+" "+EventReasonPolicyReloadFailed) {
+				t.Errorf("Unexpected event %q", event)
+			}
+		default:
+			t.Errorf("Expected an event for invalid update %q", invalid)
+		}
+	}
+
+	// the file failing to be read is reported once, not on every check
+	if err := os.Remove(file); err != nil {
+		t.Fatalf("Unable to remove policy: %v", err)
+	}
+	watcher.reload()
+	watcher.reload()
+	if len(recorder.Events) != 1 {
+		t.Errorf("Expected a single event for the file failing to be read, got %v", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	// the file read again is reported again when it fails to be read afterwards
+	writePolicy(`
+apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 3
+`)
+	watcher.reload()
+	reloaded = watcher.Policy()
+	if err := os.Remove(file); err != nil {
+		t.Fatalf("Unable to remove policy: %v", err)
+	}
+	watcher.reload()
+	if len(recorder.Events) != 1 {
+		t.Errorf("Expected an event for the file failing to be read again, got %v", len(recorder.Events))
+	}
+	if watcher.Policy() != reloaded || *reloaded.MaxNoOfPodsToEvictPerNode != 3 {
+		t.Errorf("Expected the last valid policy to be kept while the file fails to be read")
+	}
+}
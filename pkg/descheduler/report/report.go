@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report keeps the reports of the last descheduling cycles
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cycle reports a descheduling cycle
+type Cycle struct {
+	Start time.Time `json:"start"`
+	// DurationSeconds is how long the cycle took
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Nodes is the number of ready nodes the strategies considered
+	Nodes        int `json:"nodes"`
+	TotalEvicted int `json:"totalEvicted"`
+	// Error is set when the cycle was aborted
+	Error      string     `json:"error,omitempty"`
+	Strategies []Strategy `json:"strategies"`
+}
+
+// Strategy reports a strategy run in a descheduling cycle
+type Strategy struct {
+	Name string `json:"name"`
+	// Candidates is the number of pods the strategy tried to evict
+	Candidates int `json:"candidates"`
+	Evicted    int `json:"evicted"`
+	// Skipped counts the pods not evicted by reason, either the limit which prevented the
+	// eviction or the first check the pod failed
+	Skipped map[string]int `json:"skipped,omitempty"`
+	// Errors are the errors of the failed evictions
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Store keeps the reports of the last cycles
+type Store struct {
+	mu     sync.RWMutex
+	limit  int
+	cycles []Cycle
+}
+
+// NewStore creates a store of the last limit cycle reports
+func NewStore(limit int) *Store {
+	return &Store{limit: limit}
+}
+
+// Add stores the report of a cycle, dropping the oldest one when the store is full
+func (s *Store) Add(cycle Cycle) {
+	if s.limit <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cycles = append(s.cycles, cycle)
+	if len(s.cycles) > s.limit {
+		s.cycles = append([]Cycle{}, s.cycles[len(s.cycles)-s.limit:]...)
+	}
+}
+
+// List gives the reports stored, the most recent first
+func (s *Store) List() []Cycle {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cycles := make([]Cycle, 0, len(s.cycles))
+	for i := len(s.cycles) - 1; i >= 0; i-- {
+		cycles = append(cycles, s.cycles[i])
+	}
+	return cycles
+}
+
+// ServeHTTP serves the reports stored as a JSON array, the most recent first
+func (s *Store) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s.List()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
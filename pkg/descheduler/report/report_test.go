@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(2)
+	for i := 1; i <= 3; i++ {
+		store.Add(Cycle{
+			TotalEvicted: i,
+			Strategies:   []Strategy{{Name: "PodLifeTime", Candidates: i + 1, Evicted: i, Skipped: map[string]int{"LocalStorage": 1}}},
+		})
+	}
+
+	var evicted []int
+	for _, cycle := range store.List() {
+		evicted = append(evicted, cycle.TotalEvicted)
+	}
+	if expected := []int{3, 2}; !reflect.DeepEqual(evicted, expected) {
+		t.Errorf("Expected the last cycles %v, most recent first, got %v", expected, evicted)
+	}
+
+	recorder := httptest.NewRecorder()
+	store.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/descheduler/cycles", nil))
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Unexpected content type %q", contentType)
+	}
+	var served []Cycle
+	if err := json.Unmarshal(recorder.Body.Bytes(), &served); err != nil {
+		t.Fatalf("Unable to decode %q: %v", recorder.Body.String(), err)
+	}
+	if !reflect.DeepEqual(served, store.List()) {
+		t.Errorf("Expected the stored cycles to be served, got %+v", served)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
+)
+
+// Report describes the outcome of a simulation
+type Report struct {
+	Cycles int `json:"cycles"`
+	// Evictions are the pods evicted or deleted by the strategies
+	Evictions []Eviction `json:"evictions"`
+	// Replacements are the pods created by the simulated controllers for the evicted or deleted pods
+	Replacements []Replacement `json:"replacements"`
+	// Nodes is the utilization of every node before and after the simulation
+	Nodes []NodeUtilization `json:"nodes"`
+}
+
+// Eviction is a pod evicted or deleted in a simulated cycle
+type Eviction struct {
+	Cycle int `json:"cycle"`
+	audit.Record
+}
+
+// Replacement is a pod created for an evicted or deleted pod
+type Replacement struct {
+	Cycle       int    `json:"cycle"`
+	Pod         string `json:"pod"`
+	Replacement string `json:"replacement"`
+	// Node is the node the replacement was placed on, empty when it fits on no node
+	Node string `json:"node"`
+}
+
+// NodeUtilization is the utilization of a node before and after the simulation
+type NodeUtilization struct {
+	Node   string `json:"node"`
+	Before Usage  `json:"before"`
+	After  Usage  `json:"after"`
+}
+
+// Usage holds the percentage of the allocatable resources of a node requested by its pods,
+// as the thresholds of LowNodeUtilization and HighNodeUtilization
+type Usage struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	Pods   float64 `json:"pods"`
+}
+
+// utilization computes the usage of every node from the requests of the pods placed on it
+func (s *simulator) utilization() (map[string]Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes, err := s.nodes()
+	if err != nil {
+		return nil, err
+	}
+	requested, err := s.requested()
+	if err != nil {
+		return nil, err
+	}
+	usage := map[string]Usage{}
+	for _, node := range nodes {
+		usage[node.Name] = Usage{
+			CPU:    percentage(requested[node.Name], node.Status.Allocatable, v1.ResourceCPU),
+			Memory: percentage(requested[node.Name], node.Status.Allocatable, v1.ResourceMemory),
+			Pods:   percentage(requested[node.Name], node.Status.Allocatable, v1.ResourcePods),
+		}
+	}
+	return usage, nil
+}
+
+func percentage(requested, allocatable v1.ResourceList, name v1.ResourceName) float64 {
+	available, ok := allocatable[name]
+	if !ok || available.IsZero() {
+		return 0
+	}
+	used := requested[name]
+	return float64(used.MilliValue()) * 100 / float64(available.MilliValue())
+}
+
+func mergeUtilization(before, after map[string]Usage) []NodeUtilization {
+	nodes := make([]NodeUtilization, 0, len(before))
+	for name, usage := range before {
+		nodes = append(nodes, NodeUtilization{Node: name, Before: usage, After: after[name]})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+	return nodes
+}
+
+// WriteText writes the report in a human readable form
+func (r *Report) WriteText(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Simulated %d descheduling cycles, %d pods evicted\n\n", r.Cycles, len(r.Evictions))
+
+	if len(r.Evictions) > 0 {
+		fmt.Fprintln(w, "CYCLE\tSTRATEGY\tPOD\tNODE\tREASON")
+		for _, eviction := range r.Evictions {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", eviction.Cycle, eviction.Strategy, eviction.Pod, eviction.Node, eviction.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(r.Replacements) > 0 {
+		fmt.Fprintln(w, "CYCLE\tPOD\tREPLACEMENT\tNODE")
+		for _, replacement := range r.Replacements {
+			node := replacement.Node
+			if node == "" {
+				node = "<pending>"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", replacement.Cycle, replacement.Pod, replacement.Replacement, node)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "NODE\tCPU BEFORE\tCPU AFTER\tMEMORY BEFORE\tMEMORY AFTER\tPODS BEFORE\tPODS AFTER")
+	for _, node := range r.Nodes {
+		fmt.Fprintf(w, "%s\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\n", node.Node,
+			node.Before.CPU, node.After.CPU, node.Before.Memory, node.After.Memory, node.Before.Pods, node.After.Pods)
+	}
+	return w.Flush()
+}
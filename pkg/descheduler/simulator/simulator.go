@@ -0,0 +1,398 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator runs the descheduler offline against a cluster snapshot
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+var (
+	podsResource  = v1.SchemeGroupVersion.WithResource("pods")
+	podsKind      = v1.SchemeGroupVersion.WithKind("Pod")
+	nodesResource = v1.SchemeGroupVersion.WithResource("nodes")
+	nodesKind     = v1.SchemeGroupVersion.WithKind("Node")
+)
+
+// simulator holds the in-memory state of the cluster. Evicted and deleted pods owned by a controller,
+// DaemonSets aside, are replaced right away by the simulated controller, and the pods created without
+// a node are placed by the simulated scheduler. The scale of the controllers is not simulated, the
+// replicas being replaced when they are deleted.
+type simulator struct {
+	mu     sync.Mutex
+	client *fakeclientset.Clientset
+	cycle  int
+	// created counts the pods created by the simulation, for unique names
+	created      int
+	evictions    []Eviction
+	replacements []Replacement
+}
+
+// Simulate runs the policy against the objects of the snapshot for the given number of descheduling
+// cycles, the evictions being applied to the objects. The client, audit sink, dry run and descheduling
+// interval of rs are overridden, its other settings apply as when running against a cluster.
+func Simulate(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, objects []runtime.Object, cycles int) (*Report, error) {
+	s := &simulator{client: fakeclientset.NewSimpleClientset(objects...)}
+	s.client.PrependReactor("create", "pods", s.createPod)
+	s.client.PrependReactor("delete", "pods", s.deletePod)
+	for _, resource := range []string{"replicationcontrollers", "replicasets", "deployments", "statefulsets"} {
+		s.client.PrependReactor("get", resource, s.getScale)
+		s.client.PrependReactor("update", resource, s.updateScale)
+	}
+
+	rs.Client = s.client
+	rs.AuditSink = s
+	rs.DryRun = false
+	rs.DeschedulingInterval = 0
+
+	before, err := s.utilization()
+	if err != nil {
+		return nil, err
+	}
+	for cycle := 1; cycle <= cycles; cycle++ {
+		s.mu.Lock()
+		s.cycle = cycle
+		s.mu.Unlock()
+		klog.V(1).InfoS("Running simulated descheduling cycle", "cycle", cycle)
+		stopChannel := make(chan struct{})
+		err := descheduler.RunDeschedulerStrategies(ctx, rs, deschedulerPolicy, policyv1.SchemeGroupVersion.String(), stopChannel)
+		close(stopChannel)
+		if err != nil {
+			return nil, err
+		}
+	}
+	after, err := s.utilization()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &Report{
+		Cycles:       cycles,
+		Evictions:    s.evictions,
+		Replacements: s.replacements,
+		Nodes:        mergeUtilization(before, after),
+	}, nil
+}
+
+// Write records the successful evictions and deletions of the cycle, the simulator being the audit sink
+func (s *simulator) Write(record audit.Record) error {
+	// the pods recreated by the defragmentation strategies are recorded without a node
+	if record.Result != "success" || record.Node == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictions = append(s.evictions, Eviction{Cycle: s.cycle, Record: record})
+	return nil
+}
+
+func (s *simulator) Close() error {
+	return nil
+}
+
+// createPod handles the pod evictions, and places the pods created without a node
+func (s *simulator) createPod(action core.Action) (bool, runtime.Object, error) {
+	create := action.(core.CreateAction)
+	switch create.GetSubresource() {
+	case "eviction":
+		name := create.GetObject().(metav1.Object).GetName()
+		return true, nil, s.removePod(action.GetNamespace(), name)
+	case "":
+		pod, ok := create.GetObject().(*v1.Pod)
+		if !ok || pod.Spec.NodeName != "" {
+			return false, nil, nil
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if pod.Name == "" && pod.GenerateName != "" {
+			s.created++
+			pod.Name = fmt.Sprintf("%s%05d", pod.GenerateName, s.created)
+		}
+		if err := s.schedule(pod); err != nil {
+			return true, nil, err
+		}
+		if err := s.client.Tracker().Create(podsResource, pod, pod.Namespace); err != nil {
+			return true, nil, err
+		}
+		return true, pod, nil
+	}
+	return false, nil, nil
+}
+
+// deletePod deletes the pod and replaces it when it is owned by a controller
+func (s *simulator) deletePod(action core.Action) (bool, runtime.Object, error) {
+	return true, nil, s.removePod(action.GetNamespace(), action.(core.DeleteAction).GetName())
+}
+
+func (s *simulator) removePod(namespace, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, err := s.client.Tracker().Get(podsResource, namespace, name)
+	if err != nil {
+		return err
+	}
+	pod := obj.(*v1.Pod)
+	if err := s.client.Tracker().Delete(podsResource, namespace, name); err != nil {
+		return err
+	}
+
+	ownerRefs := podutil.OwnerRef(pod)
+	if len(ownerRefs) == 0 || utils.IsDaemonsetPod(ownerRefs) {
+		return nil
+	}
+
+	s.created++
+	replacement := pod.DeepCopy()
+	replacement.ResourceVersion = ""
+	replacement.Name = replacementName(pod, s.created)
+	replacement.UID = types.UID(fmt.Sprintf("simulated-%d", s.created))
+	replacement.CreationTimestamp = metav1.Now()
+	replacement.Spec.NodeName = ""
+	replacement.Status = v1.PodStatus{}
+	if err := s.schedule(replacement); err != nil {
+		return err
+	}
+	if err := s.client.Tracker().Create(podsResource, replacement, namespace); err != nil {
+		return err
+	}
+	s.replacements = append(s.replacements, Replacement{
+		Cycle:       s.cycle,
+		Pod:         namespace + "/" + pod.Name,
+		Replacement: namespace + "/" + replacement.Name,
+		Node:        replacement.Spec.NodeName,
+	})
+	return nil
+}
+
+// replacementName names the replacement of the pod the way its controller would
+func replacementName(pod *v1.Pod, n int) string {
+	if pod.GenerateName != "" {
+		return fmt.Sprintf("%s%05d", pod.GenerateName, n)
+	}
+	return fmt.Sprintf("%s-%05d", pod.Name, n)
+}
+
+// schedule places the pod on the node with the highest preferred node affinity score, then the
+// least requested one, among the schedulable nodes the pod fits on. A pod fitting on no node is left pending.
+func (s *simulator) schedule(pod *v1.Pod) error {
+	nodes, err := s.nodes()
+	if err != nil {
+		return err
+	}
+	requested, err := s.requested()
+	if err != nil {
+		return err
+	}
+
+	var preferred *nodeaffinity.PreferredSchedulingTerms
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil {
+		preferred, _ = nodeaffinity.NewPreferredSchedulingTerms(pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	podRequests, _ := utils.PodRequestsAndLimits(pod)
+
+	var best *v1.Node
+	var bestScore int64
+	var bestRequested float64
+	for _, node := range nodes {
+		if !nodeutil.IsReady(node) || nodeutil.IsNodeUnschedulable(node) || !podFitsNode(pod, node) {
+			continue
+		}
+		usage := requested[node.Name]
+		if !fitsResources(podRequests, usage, node.Status.Allocatable) {
+			continue
+		}
+		var score int64
+		if preferred != nil {
+			score = preferred.Score(node)
+		}
+		fraction := requestedFraction(usage, node.Status.Allocatable)
+		if best == nil || score > bestScore || (score == bestScore && fraction < bestRequested) {
+			best, bestScore, bestRequested = node, score, fraction
+		}
+	}
+
+	pod.Status = v1.PodStatus{Phase: v1.PodPending}
+	if best == nil {
+		klog.V(1).InfoS("Simulated pod does not fit on any node", "pod", klog.KObj(pod))
+		return nil
+	}
+	now := metav1.Now()
+	pod.Spec.NodeName = best.Name
+	pod.Status = v1.PodStatus{Phase: v1.PodRunning, StartTime: &now}
+	return nil
+}
+
+// podFitsNode checks the node selector, required node affinity and NoSchedule and NoExecute taints
+func podFitsNode(pod *v1.Pod, node *v1.Node) bool {
+	if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil || !ok {
+		return false
+	}
+	return utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	})
+}
+
+func fitsResources(podRequests, requested, allocatable v1.ResourceList) bool {
+	if pods, ok := allocatable[v1.ResourcePods]; ok {
+		used := requested[v1.ResourcePods]
+		if used.Value()+1 > pods.Value() {
+			return false
+		}
+	}
+	for name, quantity := range podRequests {
+		available, ok := allocatable[name]
+		if !ok {
+			continue
+		}
+		used := requested[name]
+		used.Add(quantity)
+		if used.Cmp(available) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// requestedFraction is the highest fraction of the allocatable cpu and memory requested on the node
+func requestedFraction(requested, allocatable v1.ResourceList) float64 {
+	var fraction float64
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		available, ok := allocatable[name]
+		if !ok || available.IsZero() {
+			continue
+		}
+		used := requested[name]
+		if f := float64(used.MilliValue()) / float64(available.MilliValue()); f > fraction {
+			fraction = f
+		}
+	}
+	return fraction
+}
+
+func (s *simulator) nodes() ([]*v1.Node, error) {
+	obj, err := s.client.Tracker().List(nodesResource, nodesKind, "")
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*v1.Node
+	for i := range obj.(*v1.NodeList).Items {
+		nodes = append(nodes, &obj.(*v1.NodeList).Items[i])
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes, nil
+}
+
+// requested sums the requests of the pods running on every node, the pods included
+func (s *simulator) requested() (map[string]v1.ResourceList, error) {
+	obj, err := s.client.Tracker().List(podsResource, podsKind, "")
+	if err != nil {
+		return nil, err
+	}
+	requested := map[string]v1.ResourceList{}
+	for i := range obj.(*v1.PodList).Items {
+		pod := &obj.(*v1.PodList).Items[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		usage, ok := requested[pod.Spec.NodeName]
+		if !ok {
+			usage = v1.ResourceList{}
+			requested[pod.Spec.NodeName] = usage
+		}
+		podRequests, _ := utils.PodRequestsAndLimits(pod)
+		for name, quantity := range podRequests {
+			total := usage[name]
+			total.Add(quantity)
+			usage[name] = total
+		}
+		pods := usage[v1.ResourcePods]
+		pods.Add(resource.MustParse("1"))
+		usage[v1.ResourcePods] = pods
+	}
+	return requested, nil
+}
+
+// getScale serves the scale subresource of the controllers, for the defragmentation strategies.
+// The replicas are read from the controller when it is part of the snapshot.
+func (s *simulator) getScale(action core.Action) (bool, runtime.Object, error) {
+	if action.GetSubresource() != "scale" {
+		return false, nil, nil
+	}
+	name := action.(core.GetAction).GetName()
+	scale := &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: action.GetNamespace()}, Spec: autoscalingv1.ScaleSpec{Replicas: 1}}
+	gvr := action.GetResource()
+	obj, err := s.client.Tracker().Get(gvr, action.GetNamespace(), name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return true, nil, err
+	}
+	if replicas := controllerReplicas(obj); replicas != nil {
+		scale.Spec.Replicas = *replicas
+	}
+	return true, scale, nil
+}
+
+// updateScale accepts the updates of the scale subresource without changing the controllers
+func (s *simulator) updateScale(action core.Action) (bool, runtime.Object, error) {
+	if action.GetSubresource() != "scale" {
+		return false, nil, nil
+	}
+	return true, action.(core.UpdateAction).GetObject(), nil
+}
+
+func controllerReplicas(obj runtime.Object) *int32 {
+	if obj == nil {
+		return nil
+	}
+	// every controller with a scale subresource has spec.replicas
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	spec, _ := content["spec"].(map[string]interface{})
+	if replicas, ok := spec["replicas"].(int64); ok {
+		r := int32(replicas)
+		return &r
+	}
+	return nil
+}
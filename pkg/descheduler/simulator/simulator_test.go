@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSimulate(t *testing.T) {
+	ctx := context.Background()
+	objects := []runtime.Object{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		test.BuildTestNode("n3", 4000, 3000, 10, func(node *v1.Node) {
+			node.Spec.Unschedulable = true
+		}),
+	}
+	for i := 0; i < 8; i++ {
+		pod := test.BuildTestPod(fmt.Sprintf("p%d", i), 400, 0, "n1", test.SetRSOwnerRef)
+		pod.GenerateName = "rs-"
+		objects = append(objects, pod)
+	}
+
+	rs, err := options.NewDeschedulerServer()
+	if err != nil {
+		t.Fatalf("Unable to initialize server: %v", err)
+	}
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"LowNodeUtilization": api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 30, v1.ResourcePods: 30},
+						TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourcePods: 50},
+					},
+				},
+			},
+		},
+	}
+
+	report, err := Simulate(ctx, rs, policy, objects, 2)
+	if err != nil {
+		t.Fatalf("Unable to simulate: %v", err)
+	}
+
+	if len(report.Evictions) == 0 {
+		t.Fatalf("Expected pods to be evicted from n1, got %+v", report)
+	}
+	if len(report.Replacements) != len(report.Evictions) {
+		t.Errorf("Expected every evicted pod to be replaced, got %d evictions and %d replacements", len(report.Evictions), len(report.Replacements))
+	}
+	for _, eviction := range report.Evictions {
+		if eviction.Node != "n1" || eviction.Strategy != "LowNodeUtilization" {
+			t.Errorf("Unexpected eviction %+v", eviction)
+		}
+	}
+	for _, replacement := range report.Replacements {
+		if replacement.Node != "n2" || !strings.HasPrefix(replacement.Replacement, "default/rs-") {
+			t.Errorf("Expected the replacements to be placed on the only schedulable underutilized node, got %+v", replacement)
+		}
+	}
+
+	usage := map[string]NodeUtilization{}
+	for _, node := range report.Nodes {
+		usage[node.Node] = node
+	}
+	if n1 := usage["n1"]; n1.Before.CPU != 80 || n1.After.CPU >= n1.Before.CPU {
+		t.Errorf("Expected the utilization of n1 to decrease from 80%%, got %+v", n1)
+	}
+	if n2 := usage["n2"]; n2.Before.Pods != 0 || n2.After.Pods != float64(10*len(report.Replacements)) {
+		t.Errorf("Expected the replacements to increase the utilization of n2, got %+v", n2)
+	}
+	if n1, n2 := usage["n1"], usage["n2"]; n1.After.Pods+n2.After.Pods != 80 {
+		t.Errorf("Expected the number of pods to be preserved, got %+v and %+v", n1, n2)
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteText(&out); err != nil {
+		t.Fatalf("Unable to write report: %v", err)
+	}
+	if !strings.Contains(out.String(), fmt.Sprintf("Simulated 2 descheduling cycles, %d pods evicted", len(report.Evictions))) {
+		t.Errorf("Unexpected report:\n%s", out.String())
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// LoadSnapshot reads the objects of a cluster snapshot from a YAML or JSON file, as written by
+// "kubectl get nodes,pods,pdb,priorityclasses -A -o yaml". The file holds either Lists of objects
+// or objects separated by "---". policy/v1beta1 PodDisruptionBudgets are converted to policy/v1,
+// and the namespaces of the pods are added when missing.
+func LoadSnapshot(snapshotFile string) ([]runtime.Object, error) {
+	data, err := ioutil.ReadFile(snapshotFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %q: %v", snapshotFile, err)
+	}
+	objects, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding snapshot file %q: %v", snapshotFile, err)
+	}
+	return objects, nil
+}
+
+func decodeSnapshot(data []byte) ([]runtime.Object, error) {
+	decoder := scheme.Codecs.UniversalDeserializer()
+	var objects []runtime.Object
+	var decode func(raw []byte) error
+	decode = func(raw []byte) error {
+		obj, _, err := decoder.Decode(raw, nil, nil)
+		if err != nil {
+			return err
+		}
+		if list, ok := obj.(*v1.List); ok {
+			for _, item := range list.Items {
+				if err := decode(item.Raw); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if pdb, ok := obj.(*policyv1beta1.PodDisruptionBudget); ok {
+			obj = convertPodDisruptionBudget(pdb)
+		}
+		objects = append(objects, obj)
+		return nil
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+		if err := decode(document); err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces := sets.NewString()
+	podNamespaces := sets.NewString()
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1.Namespace:
+			namespaces.Insert(o.Name)
+		case *v1.Pod:
+			podNamespaces.Insert(o.Namespace)
+		}
+	}
+	for _, namespace := range podNamespaces.Difference(namespaces).List() {
+		objects = append(objects, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+	}
+
+	return objects, nil
+}
+
+// convertPodDisruptionBudget converts a policy/v1beta1 PodDisruptionBudget to policy/v1, the version the descheduler reads
+func convertPodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget) *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: pdb.ObjectMeta,
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   pdb.Spec.MinAvailable,
+			Selector:       pdb.Spec.Selector,
+			MaxUnavailable: pdb.Spec.MaxUnavailable,
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			ObservedGeneration: pdb.Status.ObservedGeneration,
+			DisruptedPods:      pdb.Status.DisruptedPods,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			ExpectedPods:       pdb.Status.ExpectedPods,
+			Conditions:         pdb.Status.Conditions,
+		},
+	}
+}
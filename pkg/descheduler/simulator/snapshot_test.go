@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+)
+
+func TestDecodeSnapshot(t *testing.T) {
+	snapshot := `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: p1
+    namespace: team-a
+  spec:
+    nodeName: node1
+---
+apiVersion: policy/v1beta1
+kind: PodDisruptionBudget
+metadata:
+  name: pdb1
+  namespace: team-a
+spec:
+  minAvailable: 1
+  selector:
+    matchLabels:
+      app: p1
+status:
+  disruptionsAllowed: 1
+---
+apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: high
+value: 1000
+`
+	objects, err := decodeSnapshot([]byte(snapshot))
+	if err != nil {
+		t.Fatalf("Unable to decode snapshot: %v", err)
+	}
+
+	var kinds []string
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *v1.Node:
+			kinds = append(kinds, "Node/"+o.Name)
+		case *v1.Pod:
+			kinds = append(kinds, "Pod/"+o.Name)
+		case *policyv1.PodDisruptionBudget:
+			kinds = append(kinds, "PodDisruptionBudget/"+o.Name)
+			if o.Status.DisruptionsAllowed != 1 || o.Spec.MinAvailable.IntValue() != 1 {
+				t.Errorf("Unexpected converted PodDisruptionBudget %+v", o)
+			}
+		case *schedulingv1.PriorityClass:
+			kinds = append(kinds, "PriorityClass/"+o.Name)
+		case *v1.Namespace:
+			kinds = append(kinds, "Namespace/"+o.Name)
+		default:
+			t.Errorf("Unexpected object %T", obj)
+		}
+	}
+	expected := []string{"Node/node1", "Pod/p1", "PodDisruptionBudget/pdb1", "PriorityClass/high", "Namespace/team-a"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("Expected objects %v, got %v", expected, kinds)
+	}
+
+	if _, err := decodeSnapshot([]byte("kind: Unknown\napiVersion: v1\n")); err == nil {
+		t.Errorf("Expected objects of unknown kinds to be rejected")
+	}
+}
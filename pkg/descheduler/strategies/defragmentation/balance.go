@@ -6,9 +6,11 @@ import (
 	"github.com/mohae/deepcopy"
 
 	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/capacity"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/scheduler"
 	"time"
@@ -20,47 +22,61 @@ const (
 	balanceIterations = 10
 )
 
+// balancePods holds the parameters of BalancePodsOnNodeForDefragmentation
+type balancePods struct {
+	iterations int32
+}
+
+func newBalancePods(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateAndParseBalancePodsParams(params); err != nil {
+		return nil, err
+	}
+	// the default number of iterations is used when not set
+	b := &balancePods{iterations: balanceIterations}
+	if params != nil && params.Iterations != nil && *params.Iterations != 0 {
+		b.iterations = *params.Iterations
+	}
+	return b, nil
+}
+
+// BalancePodsOnNodeForDefragmentation swaps pods between the nodes to balance their cpu/memory consumption.
+// The pods are deleted and recreated rather than evicted, the pods which are not migratable are not swapped.
 func BalancePodsOnNodeForDefragmentation(
 	ctx context.Context,
 	client clientset.Interface,
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
 ){
-	err := validateAndParseBalancePodsParams(strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Invalid BalancePodsOnNodeForDefragmentation parameters")
-		return
-	}
+	framework.NewStrategy(
+		"BalancePodsOnNodeForDefragmentation",
+		newBalancePods,
+		framework.WithPreFilter(capacity.IsMigratable),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
+
+func (b *balancePods) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
 	klog.V(1).Infoln("***********************************************************************************")
 	klog.V(1).Infoln("Trying to balance the cpu/memory consumption across nodes")
 	klog.V(1).Infoln("***********************************************************************************")
 
-	iterations := *strategy.Params.Iterations
-	if iterations == 0 {
-		iterations = balanceIterations
-	}
-
-	if err := BalancePolicy(ctx, client, nodes, podEvictor, iterations); err != nil {
+	if err := BalancePolicy(ctx, h, nodes, b.iterations); err != nil {
 		klog.V(1).ErrorS(err, "balance the cpu/memory consumption across nodes")
-		return
 	}
-
-	return
 }
 
 // BalancePolicy actual scheduler
 func BalancePolicy(ctx context.Context,
-			 client clientset.Interface,
+			 h *framework.Handle,
 			 nodes []*v1.Node,
-			 podEvictor *evictions.PodEvictor,
 			 iterations int32,
 )error{
-	nodeInfos := capacity.GetSystemSnapshot(ctx, client, nodes)
+	nodeInfos := capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 	pivotRatio := capacity.GetPivotRatio(nodeInfos)
 	entropyBeforeBalancing := capacity.GetSystemEntropy(nodeInfos)
 
-	nodeInfos = capacity.GetSystemSnapshot(ctx, client, nodes)
+	nodeInfos = capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 	totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr := capacity.GetNodeResourceUsage(nodeInfos...)
 	klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
 	for rName, rQuanta := range totalSr {
@@ -83,14 +99,14 @@ func BalancePolicy(ctx context.Context,
 		klog.V(1).Infof("This is the %d iteration" , currIterations+1)
 		klog.V(1).Infoln("***********************************************************************************")
 
-		if err := BalanceWorkload(ctx, client, nodes, podEvictor); err != nil {
+		if err := BalanceWorkload(ctx, h, nodes); err != nil {
 			klog.V(1).ErrorS(err, "Failed to balance work load")
 			return err
 		}
 
 		time.Sleep(30)
 		klog.V(1).Infoln("***********************************************************************************")
-		nodeInfos = capacity.GetSystemSnapshot(ctx, client, nodes)
+		nodeInfos = capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 		totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr = capacity.GetNodeResourceUsage(nodeInfos...)
 		klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
 		for rName, rQuanta := range totalSr {
@@ -118,14 +134,13 @@ func BalancePolicy(ctx context.Context,
 }
 
 func BalanceWorkload(ctx context.Context,
-	client clientset.Interface,
+	h *framework.Handle,
 	nodes []*v1.Node,
-	podEvictor *evictions.PodEvictor,
 )error{
 	var nodeA, nodeB *v1.Node
 	var podA, podB *v1.Pod
 	var balanceNodeInfos []*capacity.NodeInfo
-	nodeInfos := capacity.GetSystemSnapshot(ctx, client, nodes)
+	nodeInfos := capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 	pivotRatio := capacity.GetPivotRatio(nodeInfos)
 	entropyBeforeBalancing := capacity.GetSystemEntropy(nodeInfos)
 	capacity.SortNodesBasedRatio(nodeInfos)
@@ -149,12 +164,12 @@ func BalanceWorkload(ctx context.Context,
 		isSwapped := false
 		for leftPodIndex < len(cpuPodInfos) && rightPodIndex < len(memPodInfos) {
 			//if !capacity.IsMigrated(cpuPodInfos[leftPodIndex].Pod) || !nodeutil.PodFitsCurrentNode(cpuPodInfos[leftPodIndex].Pod, nodeInfos[rightNodeIndex].Node()){
-			if !capacity.IsMigratable(cpuPodInfos[leftPodIndex].Pod){
+			if !h.PreFilter(cpuPodInfos[leftPodIndex].Pod){
 				leftPodIndex++
 				continue
 			}
 			//if !capacity.IsMigrated(memPodInfos[rightPodIndex].Pod) || !nodeutil.PodFitsCurrentNode(memPodInfos[rightNodeIndex].Pod, nodeInfos[leftNodeIndex].Node()){
-			if !capacity.IsMigratable(memPodInfos[rightPodIndex].Pod) {
+			if !h.PreFilter(memPodInfos[rightPodIndex].Pod) {
 				rightPodIndex++
 				continue
 			}
@@ -207,13 +222,13 @@ func BalanceWorkload(ctx context.Context,
 	}
 
 	if toBalance {
-		err := scheduler.SwapPods(ctx, podEvictor, podA, nodeA, podB, nodeB)
+		err := scheduler.SwapPods(ctx, h.PodEvictor, podA, nodeA, podB, nodeB)
 		if err != nil {
 			klog.V(1).ErrorS(err, "Swapping pod", "pod", klog.KObj(podA), "running on node", "node", klog.KObj(nodeA), "with pod", "pod", klog.KObj(podB), "running on node", "node", klog.KObj(nodeB))
 			return err
 		}
 		time.Sleep(30)
-		nodeInfos = capacity.GetSystemSnapshot(ctx, client, nodes)
+		nodeInfos = capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 		pivotRatio = capacity.GetPivotRatio(nodeInfos)
 		capacity.SortNodesBasedRatio(nodeInfos)
 		klog.V(1).InfoS("swapping pod", "node", klog.KObj(nodeA), "pod", klog.KObj(podA), "node", klog.KObj(nodeB), "pod", klog.KObj(podB))
@@ -312,11 +327,11 @@ func swapIfPossible(nodeInfos []*capacity.NodeInfo, nodeA, nodeB *capacity.NodeI
 
 func validateAndParseBalancePodsParams(params *api.StrategyParameters) error {
 	if params == nil {
-		return fmt.Errorf("BalancePodsParams is empty")
+		return nil
 	}
 
-	if *params.Iterations < 0 {
-		return fmt.Errorf("BalancePodsParams is empty")
+	if params.Iterations != nil && *params.Iterations < 0 {
+		return fmt.Errorf("iterations must not be negative")
 	}
 
 	return nil
@@ -3,15 +3,18 @@ package capacity
 import (
 	"context"
 	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 )
 
-func GetSystemSnapshot(ctx context.Context,	client clientset.Interface, nodes []*v1.Node)[]*NodeInfo {
+// GetSystemSnapshot builds the NodeInfo of every node from the pods assigned to it.
+// Pods are read from the podInformer cache when it is set, otherwise they are listed through the client.
+func GetSystemSnapshot(ctx context.Context, client clientset.Interface, nodes []*v1.Node, podInformer coreinformers.PodInformer) []*NodeInfo {
 	var nodeInfos []*NodeInfo
 	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		pods, err := podutil.ListPodsOnANode(ctx, client, node, podutil.WithPodInformer(podInformer))
 		if err != nil {
 			klog.V(2).InfoS("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
 			continue
@@ -27,7 +30,7 @@ func GetSystemSnapshot(ctx context.Context,	client clientset.Interface, nodes []
 	return nodeInfos
 }
 
-func GetNodeResourceUsage(nodeInfos ...*NodeInfo)(int64, int64, map[string]int64, int64, int64, map[string]int64, int64, int64, map[string]int64){
+func GetNodeResourceUsage(nodeInfos ...*NodeInfo) (int64, int64, map[string]int64, int64, int64, map[string]int64, int64, int64, map[string]int64) {
 	var totalCpu, totalMem int64
 	var usedCpu, usedMem int64
 	totalSr := make(map[string]int64)
@@ -54,4 +57,3 @@ func GetNodeResourceUsage(nodeInfos ...*NodeInfo)(int64, int64, map[string]int64
 
 	return totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr
 }
-
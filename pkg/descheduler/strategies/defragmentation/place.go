@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/capacity"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/scheduler"
@@ -22,66 +23,67 @@ const (
 	migrateIterations = 10
 )
 
+// placePods holds the parameters of PlacePodsOnNodeForDefragmentation
+type placePods struct {
+	iterations int32
+}
+
+func newPlacePods(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateAndParsePlacePodsParams(params); err != nil {
+		return nil, err
+	}
+	// the default number of iterations is used when not set
+	p := &placePods{iterations: migrateIterations}
+	if params != nil && params.Iterations != nil && *params.Iterations != 0 {
+		p.iterations = *params.Iterations
+	}
+	return p, nil
+}
+
 //PlacePodsOnNodeForDefragmentation place the pod on the node
+// The pending pods in the namespaces and matching the label selector of the strategy are placed, migrating
+// the pods in the way in the order of the sorters of the strategy, the pods requesting the least resources first.
 func PlacePodsOnNodeForDefragmentation(
 	ctx context.Context,
 	client clientset.Interface,
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
 ){
-	err := validateAndParsePlacePodsParams(strategy.Params)
-	if err != nil {
-		klog.V(1).ErrorS(err, "Invalid PlacePodsOnNodeForDefragmentation parameters")
-		return
-	}
+	framework.NewStrategy(
+		"PlacePodsOnNodeForDefragmentation",
+		newPlacePods,
+		framework.WithPreFilter(capacity.IsPlaceable),
+		framework.WithDefaultSortBy(scheduler.SortByLeastRequests),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
+func (p *placePods) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
 	klog.V(1).Infoln("***********************************************************************************")
 	klog.V(1).Infoln("Trying to place pod across nodes")
 	klog.V(1).Infoln("***********************************************************************************")
 
-	iterations := *strategy.Params.Iterations
-	if iterations == 0 {
-		iterations = migrateIterations
-	}
-
-	if err := PlacePolicy(ctx, client, strategy, nodes, podEvictor, iterations); err != nil {
+	if err := PlacePolicy(ctx, h, nodes, p.iterations); err != nil {
 		klog.V(1).ErrorS(err, "place pod across nodes")
 	}
-
-	return
 }
 
 func PlacePolicy(ctx context.Context,
-	client clientset.Interface,
-	strategy api.DeschedulerStrategy,
+	h *framework.Handle,
 	nodes []*v1.Node,
-	podEvictor *evictions.PodEvictor,
 	iterations int32,
 )error{
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params != nil && strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
-	}
-
-	var labelSelector *metav1.LabelSelector
-	if strategy.Params != nil {
-		labelSelector = strategy.Params.LabelSelector
-	}
-
-	nodeInfos := capacity.GetSystemSnapshot(ctx, client, nodes)
+	nodeInfos := capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 	var currIterations int32
 	for currIterations <  iterations {
 		pods, err := podutil.ListPods(ctx,
-			client,
+			h.Client,
 			podutil.WithFilter(func(pod *v1.Pod) bool {
 				return utils.IsPendingPod(pod)
 			}),
-			podutil.WithFilter(capacity.IsPlaceable),
-			podutil.WithNamespaces(includedNamespaces),
-			podutil.WithoutNamespaces(excludedNamespaces),
-			podutil.WithLabelSelector(labelSelector),
+			podutil.WithFilter(h.PreFilter),
+			podutil.WithPodInformer(h.PodInformer),
 		)
 		if err != nil {
 			klog.V(1).ErrorS(err, "list pod error")
@@ -89,7 +91,7 @@ func PlacePolicy(ctx context.Context,
 		}
 
 		if len(pods) == 0 {
-			klog.V(1).InfoS("No pending pod", "includedNamespaces", includedNamespaces, "excludedNamespaces", excludedNamespaces, "labelSelector", labelSelector)
+			klog.V(1).InfoS("No pending pod")
 			break
 		}
 
@@ -123,13 +125,13 @@ func PlacePolicy(ctx context.Context,
 
 		pod := pods[0]
 		podInfo := capacity.NewPodInfo(pod)
-		if err := PlaceWorkload(ctx, podEvictor, podInfo, nodeInfos); err != nil {
+		if err := PlaceWorkload(ctx, h.PodEvictor, podInfo, nodeInfos, h.Sort); err != nil {
 			klog.V(1).ErrorS(err, "place", podInfo.Pod.GetNamespace(), podInfo.Pod.GetName())
 		}
 
 		time.Sleep(30)
 		klog.V(1).Infoln("***********************************************************************************")
-		nodeInfos = capacity.GetSystemSnapshot(ctx, client, nodes)
+		nodeInfos = capacity.GetSystemSnapshot(ctx, h.Client, nodes, h.PodInformer)
 		totalCpu, totalMem, totalSr, usedCpu, usedMem, usedSr, availableCpu, availableMem, availableSr = capacity.GetNodeResourceUsage(nodeInfos...)
 		klog.V(1).Infof("Nodes resource usage: total-cpu:%.3f, total-memory:%.3fMi, used-cpu:%.3f, used-memory:%.3fMi, available-cpu:%.3f, available-memory:%.3fMi", float64(totalCpu)/1000, float64(totalMem)/capacity.MB, float64(usedCpu)/1000, float64(usedMem)/capacity.MB, float64(availableCpu)/1000, float64(availableMem)/capacity.MB)
 		for rName, rQuanta := range totalSr {
@@ -153,7 +155,8 @@ func PlacePolicy(ctx context.Context,
 	return nil
 }
 
-func PlaceWorkload(ctx context.Context, podEvictor *evictions.PodEvictor, podInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo)error{
+// PlaceWorkload places the pending pod, migrating the pods in the way in the order of sortPods
+func PlaceWorkload(ctx context.Context, podEvictor *evictions.PodEvictor, podInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo, sortPods func([]*v1.Pod))error{
 	//bSinglePod := false
 	//if len(podInfo.Pod.GetOwnerReferences()) == 0 {
 	//	bSinglePod = true
@@ -167,7 +170,7 @@ func PlaceWorkload(ctx context.Context, podEvictor *evictions.PodEvictor, podInf
 	//}
 
 	klog.V(1).InfoS("start to place pending pod", "pod", klog.KObj(podInfo.Pod))
-	_, toNodeInfo, ok := scheduler.PlacePod(ctx, podEvictor, podInfo, nodeInfos)
+	_, toNodeInfo, ok := scheduler.PlacePod(ctx, podEvictor, podInfo, nodeInfos, sortPods)
 	if !ok {
 		//if bSinglePod {
 		//	if _, err := podEvictor.Client().CoreV1().Pods(podInfo.Pod.GetNamespace()).Create(ctx, podInfo.Pod, metav1.CreateOptions{}); err != nil {
@@ -193,8 +196,8 @@ func validateAndParsePlacePodsParams(params *api.StrategyParameters)error{
 		return nil
 	}
 
-	if *params.Iterations < 0 {
-		return fmt.Errorf("PlacePodsParams is empty")
+	if params.Iterations != nil && *params.Iterations < 0 {
+		return fmt.Errorf("iterations must not be negative")
 	}
 
 	// At most one of include/exclude can be set
@@ -2,8 +2,14 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -19,6 +25,11 @@ func SwapPods(ctx context.Context, podEvictor *evictions.PodEvictor, podA *v1.Po
 		return err
 	}
 
+	// do not start migrating podB once shutting down
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	//delete podB from nodeB
 	//add podA to nodeB
 	if err := MigratePod(ctx, podEvictor, podB, nodeB, nodeA, true); err != nil {
@@ -29,7 +40,7 @@ func SwapPods(ctx context.Context, podEvictor *evictions.PodEvictor, podA *v1.Po
 	// pod交换时, 副本控制器控制的副本延迟删除, 防止pod删除后, pod重新调度原节点
 	for _, owner := range podB.GetOwnerReferences() {
 		if owner.Kind == "ReplicationController" || owner.Kind == "ReplicaSet" {
-			if err := deletePod(ctx, podEvictor, podB, nodeB); err != nil {
+			if err := deletePod(ctx, podEvictor, podB, nodeB, fmt.Sprintf("swapped with pod %s", klog.KObj(podA)), *podEvictor.DeleteOptions()); err != nil {
 				klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(podB))
 			}
 			break
@@ -38,7 +49,7 @@ func SwapPods(ctx context.Context, podEvictor *evictions.PodEvictor, podA *v1.Po
 
 	for _, owner := range podA.GetOwnerReferences() {
 		if owner.Kind == "ReplicationController" || owner.Kind == "ReplicaSet" {
-			if err := deletePod(ctx, podEvictor, podA, nodeA); err != nil {
+			if err := deletePod(ctx, podEvictor, podA, nodeA, fmt.Sprintf("swapped with pod %s", klog.KObj(podB)), *podEvictor.DeleteOptions()); err != nil {
 				klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(podB))
 			}
 			break
@@ -48,6 +59,9 @@ func SwapPods(ctx context.Context, podEvictor *evictions.PodEvictor, podA *v1.Po
 	return nil
 }
 
+// defragmentationAuditStrategy is the strategy of the audit records of the pods deleted and recreated by the defragmentation
+const defragmentationAuditStrategy = "Defragmentation"
+
 type Controller struct {
 	controllerType string
 	Name	string
@@ -57,6 +71,7 @@ func MigratePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.P
 	if fromNode == nil {
 		return nil
 	}
+	reason := fmt.Sprintf("migrated to node %s", toNode.GetName())
 
 	var controller Controller
 	for _, owner := range podutil.OwnerRef(pod) {
@@ -106,93 +121,65 @@ func MigratePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.P
 	//}
 
 	if controller.controllerType == "" {
+		// the pod is kept when shutting down before its deletion
+		if err := ctx.Err(); err != nil {
+			klog.V(1).InfoS("Shutting down, not migrating the pod", "pod", klog.KObj(pod))
+			return err
+		}
 		klog.V(1).InfoS("delete pod", "pod",  klog.KObj(pod), "on node", klog.KObj(fromNode))
-		if err := deletePod(ctx, podEvictor, pod, fromNode); err != nil {
+		// the pod is recreated under the same name, it is deleted right away rather than with a grace period
+		deleteOptions := *podEvictor.DeleteOptions()
+		deleteOptions.GracePeriodSeconds = new(int64)
+		if err := deletePod(ctx, podEvictor, pod, fromNode, reason, deleteOptions); err != nil {
 			klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(pod))
 			return err
 		}
+		// the context of the cycle may be cancelled by a shutdown, recreating the deleted pod must not be
+		recreateCtx, cancel := context.WithTimeout(context.Background(), podRecreateTimeout)
+		defer cancel()
+		// the pod may still be terminating, e.g. held by a finalizer, the name is free once it is gone
+		if err := waitForPodDeletion(recreateCtx, podEvictor, pod); err != nil {
+			klog.ErrorS(err, "Error wait for pod deletion", "pod", klog.KObj(pod))
+			podEvictor.Audit(pod, nil, defragmentationAuditStrategy, fmt.Sprintf("rescheduled to node %s", toNode.GetName()), "error")
+			return err
+		}
 		klog.V(1).InfoS("reschedule pod", "pod",  klog.KObj(pod), "to node", klog.KObj(toNode))
-		if err := reSchedulePod(ctx, podEvictor, pod, toNode.GetName(), isSwap); err != nil {
+		if err := reSchedulePod(recreateCtx, podEvictor, pod, toNode.GetName(), isSwap); err != nil {
 			klog.ErrorS(err, "Error reschedule pod", "pod", klog.KObj(pod))
 			return err
 		}
 	}else if controller.controllerType == "ReplicationController" {
-		 s, err := podEvictor.Client().CoreV1().ReplicationControllers(pod.GetNamespace()).GetScale(ctx, controller.Name, metav1.GetOptions{})
-		 if err != nil {
-			 klog.ErrorS(err, "Error get pod controller rc scale", "pod", klog.KObj(pod))
-			 return err
-		 }
-		sc := *s
-		sc.Spec.Replicas +=1
-		if _, err := podEvictor.Client().CoreV1().ReplicationControllers(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil{
-			klog.ErrorS(err, "Error update pod controller rc scale", "pod", klog.KObj(pod))
-			return err
-		}
-
-		if !isSwap {
-			if err := deletePod(ctx, podEvictor, pod, fromNode); err != nil {
-				klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(pod))
+		rcs := podEvictor.Client().CoreV1().ReplicationControllers(pod.GetNamespace())
+		return migrateScaledPod(ctx, podEvictor, pod, fromNode, reason, isSwap,
+			func(ctx context.Context) (*autoscalingv1.Scale, error) {
+				return rcs.GetScale(ctx, controller.Name, metav1.GetOptions{})
+			},
+			func(ctx context.Context, sc *autoscalingv1.Scale) error {
+				_, err := rcs.UpdateScale(ctx, controller.Name, sc, metav1.UpdateOptions{})
 				return err
-			}
-		}
-
-		sc.ResourceVersion = ""
-		sc.Spec.Replicas -=1
-		if _, err := podEvictor.Client().CoreV1().ReplicationControllers(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil{
-			klog.ErrorS(err, "Error update pod rc", "pod", klog.KObj(pod))
-			return err
-		}
+			})
 	}else if controller.controllerType == "ReplicaSet" {
-		s, err := podEvictor.Client().AppsV1().ReplicaSets(pod.GetNamespace()).GetScale(ctx, controller.Name, metav1.GetOptions{})
-		if err != nil {
-			klog.ErrorS(err, "Error get pod controller rs scale", "pod", klog.KObj(pod))
-			return err
-		}
-		sc := *s
-		sc.Spec.Replicas +=1
-		if _, err := podEvictor.Client().AppsV1().ReplicaSets(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil{
-			klog.ErrorS(err, "Error update pod controller rs scale", "pod", klog.KObj(pod))
-			return err
-		}
-		if !isSwap {
-			if err := deletePod(ctx, podEvictor, pod, fromNode); err != nil {
-				klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(pod))
+		replicaSets := podEvictor.Client().AppsV1().ReplicaSets(pod.GetNamespace())
+		return migrateScaledPod(ctx, podEvictor, pod, fromNode, reason, isSwap,
+			func(ctx context.Context) (*autoscalingv1.Scale, error) {
+				return replicaSets.GetScale(ctx, controller.Name, metav1.GetOptions{})
+			},
+			func(ctx context.Context, sc *autoscalingv1.Scale) error {
+				_, err := replicaSets.UpdateScale(ctx, controller.Name, sc, metav1.UpdateOptions{})
 				return err
-			}
-		}
-		sc.ResourceVersion = ""
-		sc.Spec.Replicas -=1
-		if _, err := podEvictor.Client().AppsV1().ReplicaSets(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil{
-			klog.ErrorS(err, "Error update pod controller rs scale", "pod", klog.KObj(pod))
-			return err
-		}
+			})
 	}else if controller.controllerType == "Deployment"{
-		s, err := podEvictor.Client().AppsV1().Deployments(pod.GetNamespace()).GetScale(ctx, controller.Name, metav1.GetOptions{})
-		if err != nil {
-			klog.ErrorS(err, "Error get pod deployment", "pod", klog.KObj(pod))
-			return err
-		}
-		sc := *s
-		sc.Spec.Replicas += 1
-		if _, err := podEvictor.Client().AppsV1().Deployments(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil {
-			klog.ErrorS(err, "Error update pod controller deployment scale", "pod", klog.KObj(pod))
-			return err
-		}
-		if !isSwap {
-			if err := deletePod(ctx, podEvictor, pod, fromNode); err != nil {
-				klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(pod))
+		deployments := podEvictor.Client().AppsV1().Deployments(pod.GetNamespace())
+		return migrateScaledPod(ctx, podEvictor, pod, fromNode, reason, isSwap,
+			func(ctx context.Context) (*autoscalingv1.Scale, error) {
+				return deployments.GetScale(ctx, controller.Name, metav1.GetOptions{})
+			},
+			func(ctx context.Context, sc *autoscalingv1.Scale) error {
+				_, err := deployments.UpdateScale(ctx, controller.Name, sc, metav1.UpdateOptions{})
 				return err
-			}
-		}
-
-		sc.ResourceVersion = ""
-		sc.Spec.Replicas -= 1
-		if _, err := podEvictor.Client().AppsV1().Deployments(pod.GetNamespace()).UpdateScale(ctx, controller.Name, &sc, metav1.UpdateOptions{}); err != nil {
-			klog.ErrorS(err, "Error update pod controller deployment scale", "pod", klog.KObj(pod))
-			return err
-		}
+			})
 	}else if controller.controllerType == "Job" || controller.controllerType == "Operator"{
-		if err := deletePod(ctx, podEvictor, pod, fromNode); err != nil {
+		if err := deletePod(ctx, podEvictor, pod, fromNode, reason, *podEvictor.DeleteOptions()); err != nil {
 			klog.ErrorS(err, "Error delete pod", "pod", klog.KObj(pod))
 			return err
 		}
@@ -201,6 +188,56 @@ func MigratePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.P
 	return nil
 }
 
+// scaleRestoreTimeout bounds restoring the replica count of a controller once the descheduler is shutting down
+const scaleRestoreTimeout = 30 * time.Second
+
+// podRecreateTimeout bounds waiting for a deleted pod without controller to be gone and recreating it,
+// be the descheduler shutting down or not
+const podRecreateTimeout = 30 * time.Second
+
+// podDeletionPollInterval is how often a deleted pod is checked for being gone before it is recreated
+const podDeletionPollInterval = 500 * time.Millisecond
+
+// migrateScaledPod migrates a pod of a scalable controller: the controller is scaled up by one, the pod deleted
+// unless swapped and the controller scaled back to its original replica count. The replica count is restored
+// even when ctx is cancelled meanwhile or the pod could not be deleted, the pod being kept when ctx is cancelled
+// before its deletion.
+func migrateScaledPod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.Pod, fromNode *v1.Node, reason string, isSwap bool,
+	getScale func(ctx context.Context) (*autoscalingv1.Scale, error), updateScale func(ctx context.Context, sc *autoscalingv1.Scale) error) error {
+	s, err := getScale(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Error get pod controller scale", "pod", klog.KObj(pod))
+		return err
+	}
+	sc := *s
+	sc.Spec.Replicas += 1
+	if err := updateScale(ctx, &sc); err != nil {
+		klog.ErrorS(err, "Error update pod controller scale", "pod", klog.KObj(pod))
+		return err
+	}
+
+	var deleteErr error
+	if !isSwap {
+		if ctx.Err() != nil {
+			klog.V(1).InfoS("Shutting down, rolling back the migration", "pod", klog.KObj(pod))
+			deleteErr = ctx.Err()
+		} else if deleteErr = deletePod(ctx, podEvictor, pod, fromNode, reason, *podEvictor.DeleteOptions()); deleteErr != nil {
+			klog.ErrorS(deleteErr, "Error delete pod", "pod", klog.KObj(pod))
+		}
+	}
+
+	// the context of the cycle may be cancelled by a shutdown, restoring the replica count must not be
+	restoreCtx, cancel := context.WithTimeout(context.Background(), scaleRestoreTimeout)
+	defer cancel()
+	sc.ResourceVersion = ""
+	sc.Spec.Replicas -= 1
+	if err := updateScale(restoreCtx, &sc); err != nil {
+		klog.ErrorS(err, "Error restore pod controller scale", "pod", klog.KObj(pod), "replicas", sc.Spec.Replicas)
+		return err
+	}
+	return deleteErr
+}
+
 func reSchedulePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.Pod, toNode string, isSwap bool)error{
 	schedulePod := pod.DeepCopy()
 	schedulePod.SetResourceVersion("")
@@ -213,19 +250,42 @@ func reSchedulePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v
 
 	if _, err := podEvictor.Client().CoreV1().Pods(schedulePod.Namespace).Create(ctx, schedulePod, metav1.CreateOptions{}); err != nil {
 		klog.V(1).ErrorS(err, "Error reschedule pod", klog.KObj(pod), toNode)
+		podEvictor.Audit(pod, nil, defragmentationAuditStrategy, fmt.Sprintf("rescheduled to node %s", toNode), "error")
 		return err
 	}
+	podEvictor.Audit(pod, nil, defragmentationAuditStrategy, fmt.Sprintf("rescheduled to node %s", toNode), "success")
 
 	return nil
 }
 
-func deletePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.Pod, node *v1.Node)error{
-	gracePeriodSeconds := int64(0)
-	if err := podEvictor.Client().CoreV1().Pods(pod.GetNamespace()).Delete(ctx, pod.GetName(), metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds}); err != nil {
+// waitForPodDeletion waits until the pod is gone, a pod with another UID under its name being a new one
+func waitForPodDeletion(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.Pod) error {
+	return wait.PollImmediateUntil(podDeletionPollInterval, func() (bool, error) {
+		current, err := podEvictor.Client().CoreV1().Pods(pod.GetNamespace()).Get(ctx, pod.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			klog.V(3).InfoS("Unable to get deleted pod, retrying", "pod", klog.KObj(pod), "err", err)
+			return false, nil
+		}
+		return current.UID != pod.UID, nil
+	}, ctx.Done())
+}
+
+// deletePod deletes the pod with options, the ones of the policy or strategy unless the pod must be gone right away
+func deletePod(ctx context.Context, podEvictor *evictions.PodEvictor, pod *v1.Pod, node *v1.Node, reason string, options metav1.DeleteOptions)error{
+	if err := podEvictor.Client().CoreV1().Pods(pod.GetNamespace()).Delete(ctx, pod.GetName(), options); err != nil {
 	//if _, err := podEvictor.EvictPod(ctx, pod, node, "defragment"); err != nil {
 		klog.ErrorS(err, "Error evicting pod", klog.KObj(pod))
+		podEvictor.EventRecorder().Event(pod, v1.EventTypeWarning, evictions.EventReasonDescheduleFailed, fmt.Sprintf("pod deletion by sigs.k8s.io/descheduler defragmentation failed: %v", err))
+		podEvictor.Audit(pod, node, defragmentationAuditStrategy, reason, "error")
+		podEvictor.CountDeletion(pod, err)
 		return err
 	}
+	podEvictor.Audit(pod, node, defragmentationAuditStrategy, reason, "success")
+	podEvictor.CountDeletion(pod, nil)
+	podEvictor.EventRecorder().Event(pod, v1.EventTypeNormal, evictions.EventReasonDescheduled, fmt.Sprintf("pod deleted by sigs.k8s.io/descheduler to defragment node %s", node.GetName()))
 
 	return nil
 }
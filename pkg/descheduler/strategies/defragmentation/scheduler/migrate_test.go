@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestMigratePodRestoresReplicas(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	trueVar := true
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "rs",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "d", Controller: &trueVar}},
+	}}
+
+	tests := []struct {
+		description string
+		cancelled   bool
+		podExists   bool
+		expectErr   bool
+		expectPod   bool
+	}{
+		{description: "migrated pod", podExists: true},
+		{description: "shutdown before the deletion", cancelled: true, podExists: true, expectErr: true, expectPod: true},
+		{description: "failed deletion", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: rs.Name, Controller: &trueVar}}
+			objects := []runtime.Object{n1, n2, rs}
+			if tc.podExists {
+				objects = append(objects, pod)
+			}
+			client := fake.NewSimpleClientset(objects...)
+
+			var replicas int32 = 2
+			var updates []int32
+			client.PrependReactor("get", "deployments", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: replicas}}, nil
+			})
+			client.PrependReactor("update", "deployments", func(action core.Action) (bool, runtime.Object, error) {
+				scale := action.(core.UpdateAction).GetObject().(*autoscalingv1.Scale)
+				replicas = scale.Spec.Replicas
+				updates = append(updates, replicas)
+				return true, scale, nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancelled {
+				cancel()
+			}
+			podEvictor := evictions.NewPodEvictor(client, "v1", false, 0, 0, 0, []*v1.Node{n1, n2}, false, false, false)
+			err := MigratePod(ctx, podEvictor, pod, n1, n2, false)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("Unexpected error %v", err)
+			}
+			if expected := []int32{3, 2}; !reflect.DeepEqual(updates, expected) {
+				t.Errorf("Expected the replicas to be updated to %v, got %v", expected, updates)
+			}
+			_, err = client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			if exists := err == nil; exists != tc.expectPod {
+				t.Errorf("Expected the pod to exist: %v, got %v", tc.expectPod, exists)
+			}
+		})
+	}
+}
+
+func TestMigratePodRecreatesPodWithoutController(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	tests := []struct {
+		description      string
+		cancelBefore     bool
+		cancelOnDeletion bool
+		expectErr        bool
+		expectDeletion   bool
+	}{
+		{description: "migrated pod", expectDeletion: true},
+		{description: "shutdown before the deletion", cancelBefore: true, expectErr: true},
+		{description: "shutdown during the migration", cancelOnDeletion: true, expectDeletion: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+			pod.UID = "uid"
+			client := fake.NewSimpleClientset(n1, n2, pod)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancelBefore {
+				cancel()
+			}
+			deleted := false
+			client.PrependReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				deleted = true
+				if tc.cancelOnDeletion {
+					cancel()
+				}
+				return false, nil, nil
+			})
+
+			podEvictor := evictions.NewPodEvictor(client, "v1", false, 0, 0, 0, []*v1.Node{n1, n2}, false, false, false)
+			err := MigratePod(ctx, podEvictor, pod, n1, n2, false)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("Unexpected error %v", err)
+			}
+			if deleted != tc.expectDeletion {
+				t.Errorf("Expected the pod to be deleted: %v, got %v", tc.expectDeletion, deleted)
+			}
+			// the pod is either kept or recreated, unscheduled
+			got, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected the pod to exist, got %v", err)
+			}
+			if recreated := got.UID == ""; recreated != tc.expectDeletion {
+				t.Errorf("Expected the pod to be recreated: %v, got %v", tc.expectDeletion, recreated)
+			}
+		})
+	}
+}
+
+func TestMigratePodWaitsForTerminatingPodWithoutController(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	pod := test.BuildTestPod("p1", 100, 0, n1.Name, nil)
+	pod.UID = "uid"
+	client := fake.NewSimpleClientset(n1, n2, pod)
+
+	// the deleted pod is kept terminating, as with a finalizer, until it has been checked a few times
+	gvr := v1.SchemeGroupVersion.WithResource("pods")
+	gets := 0
+	client.PrependReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		terminating := pod.DeepCopy()
+		now := metav1.Now()
+		terminating.DeletionTimestamp = &now
+		return true, nil, client.Tracker().Update(gvr, terminating, pod.Namespace)
+	})
+	client.PrependReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if gets++; gets == 2 {
+			if err := client.Tracker().Delete(gvr, pod.Namespace, pod.Name); err != nil {
+				return true, nil, err
+			}
+		}
+		return false, nil, nil
+	})
+
+	podEvictor := evictions.NewPodEvictor(client, "v1", false, 0, 0, 0, []*v1.Node{n1, n2}, false, false, false)
+	if err := MigratePod(context.Background(), podEvictor, pod, n1, n2, false); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if gets < 2 {
+		t.Errorf("Expected the pod to be recreated once gone, it was checked %d times", gets)
+	}
+	got, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the pod to exist, got %v", err)
+	}
+	if got.UID != "" || got.DeletionTimestamp != nil {
+		t.Errorf("Expected the pod to be recreated, got %v", got.ObjectMeta)
+	}
+}
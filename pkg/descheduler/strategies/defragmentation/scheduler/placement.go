@@ -6,21 +6,22 @@ import (
 	"k8s.io/klog/v2"
 	"math"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/cantor"
 	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/capacity"
 	"sort"
-	"strings"
 )
 
-func PlacePod(ctx context.Context, podEvictor *evictions.PodEvictor, podInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo)([]*capacity.PodInfo, *capacity.NodeInfo, bool){
+// PlacePod makes room for the pending pod, migrating pods in the order of sortPods when several of them have to be
+func PlacePod(ctx context.Context, podEvictor *evictions.PodEvictor, podInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo, sortPods func([]*v1.Pod))([]*capacity.PodInfo, *capacity.NodeInfo, bool){
 	var migratePods []*capacity.PodInfo
 	singleMigratePod, singleMigrateNode, placedSingle := placeCapacity(ctx, podEvictor, podInfo, nodeInfos)
 	if placedSingle {
 		migratePods = append(migratePods, singleMigratePod)
 		return migratePods, singleMigrateNode, placedSingle
 	}else {
-		multiMigratePods, multiMigrateNode, placedMulti := placeCapacityWithMultipleMigration(ctx, podEvictor, podInfo, nodeInfos)
+		multiMigratePods, multiMigrateNode, placedMulti := placeCapacityWithMultipleMigration(ctx, podEvictor, podInfo, nodeInfos, sortPods)
 		if placedMulti {
 			migratePods = append(migratePods, multiMigratePods...)
 			return migratePods, multiMigrateNode, placedMulti
@@ -203,7 +204,7 @@ func computeMinimumMigrateablePod(eligiblePods []*capacity.PodInfo)*capacity.Pod
 	return eligiblePods[0]
 }
 
-func placeCapacityWithMultipleMigration(ctx context.Context, podEvictor *evictions.PodEvictor, placePodInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo)([]*capacity.PodInfo, *capacity.NodeInfo, bool){
+func placeCapacityWithMultipleMigration(ctx context.Context, podEvictor *evictions.PodEvictor, placePodInfo *capacity.PodInfo, nodeInfos []*capacity.NodeInfo, sortPods func([]*v1.Pod))([]*capacity.PodInfo, *capacity.NodeInfo, bool){
 	if !checkPlacementElibility(placePodInfo, nodeInfos) {
 		fromNode, toNode, directlyPlaceOnNode := computeNormalPlacement(placePodInfo, nodeInfos)
 		if directlyPlaceOnNode {
@@ -229,10 +230,10 @@ func placeCapacityWithMultipleMigration(ctx context.Context, podEvictor *evictio
 				eligiblePods := computeMultipleEligiblePods(placePodInfo, nodeInfo)
 				if len(eligiblePods) != 0 {
 					currentEligiblePods := []*capacity.PodInfo{eligiblePods[0]}
-					pods := computeMinimumMigrateablePods(placePodInfo, eligiblePods, currentEligiblePods, nodeInfo)
+					pods := computeMinimumMigrateablePods(placePodInfo, eligiblePods, currentEligiblePods, nodeInfo, sortPods)
 					for _, pod := range pods {
 						if _, _, ok := placeCapacity(ctx, podEvictor, pod, nodeInfos); !ok {
-							placeCapacityWithMultipleMigration(ctx, podEvictor, pod, nodeInfos)
+							placeCapacityWithMultipleMigration(ctx, podEvictor, pod, nodeInfos, sortPods)
 						}
 					}
 					return pods, nodeInfo, true
@@ -265,7 +266,7 @@ func computeMultipleEligiblePods(placePodInfo *capacity.PodInfo, nodeInfo *capac
 	return podInfos
 }
 
-func computeMinimumMigrateablePods(placePodInfo *capacity.PodInfo, multipleEligiblePods, currentEligiblePods []*capacity.PodInfo, nodeInfo *capacity.NodeInfo)[]*capacity.PodInfo{
+func computeMinimumMigrateablePods(placePodInfo *capacity.PodInfo, multipleEligiblePods, currentEligiblePods []*capacity.PodInfo, nodeInfo *capacity.NodeInfo, sortPods func([]*v1.Pod))[]*capacity.PodInfo{
 	totalCpuMiliCore := computeTotalCpuMilicore(currentEligiblePods...)
 	totalMemMB := computeTotalMemoryMB(currentEligiblePods...)
 	placedRes, placeCpu, placeMem := capacity.CalculateResource(placePodInfo.Pod)
@@ -282,25 +283,25 @@ func computeMinimumMigrateablePods(placePodInfo *capacity.PodInfo, multipleEligi
 		cpuDiff, memDiff := placeCpu - nodeInfo.Available.MilliCPU, placeMem - nodeInfo.Available.Memory
 		if cpuDiff > 0 && memDiff > 0 {
 			if totalCpuMiliCore >= cpuDiff && totalMemMB >= memDiff {
-				return sortMigrateablePods(currentEligiblePods)
+				return sortMigrateablePods(currentEligiblePods, sortPods)
 			}
 		} else if cpuDiff > 0 && memDiff < 0 {
 			if totalCpuMiliCore >= cpuDiff {
-				return sortMigrateablePods(currentEligiblePods)
+				return sortMigrateablePods(currentEligiblePods, sortPods)
 			}
 		} else if memDiff > 0 && cpuDiff < 0 {
 			if totalCpuMiliCore >= cpuDiff {
-				return sortMigrateablePods(currentEligiblePods)
+				return sortMigrateablePods(currentEligiblePods, sortPods)
 			}
 		}
 
 		if len(multipleEligiblePods) <= 1 {
-			return sortMigrateablePods(currentEligiblePods)
+			return sortMigrateablePods(currentEligiblePods, sortPods)
 		}
 	}
 	currentPod := multipleEligiblePods[1]
 	currentEligiblePods = append(currentEligiblePods, currentPod)
-	return computeMinimumMigrateablePods(placePodInfo, multipleEligiblePods[1:], currentEligiblePods, nodeInfo)
+	return computeMinimumMigrateablePods(placePodInfo, multipleEligiblePods[1:], currentEligiblePods, nodeInfo, sortPods)
 }
 
 func computeTotalMemoryMB(podInfos ...*capacity.PodInfo)int64 {
@@ -332,38 +333,42 @@ func computeTotalScalarResource(name v1.ResourceName, podInfos ...*capacity.PodI
 	return sum
 }
 
-func sortMigrateablePods(pods []*capacity.PodInfo)[]*capacity.PodInfo{
-	podScore := make(map[string]int)
-	keys := make([]string, 0, len(pods))
-	for _, pod := range pods {
-		key := pod.Pod.GetNamespace() + "-" + pod.Pod.GetName()
-		keys = append(keys, key)
-		var pairedScore int
-		res, cpu, mem := capacity.CalculateResource(pod.Pod)
-		for _, rQuant := range res.ScalarResources {
-			pairedScore += cantor.Pair(int(math.Abs(float64(rQuant))), int(math.Abs(float64(cpu))))
-			pairedScore += cantor.Pair(int(math.Abs(float64(rQuant))), int(math.Abs(float64(mem)/capacity.MB)))
-		}
-		pairedScore += cantor.Pair(int(math.Abs(float64(cpu))), int(math.Abs(float64(mem)/capacity.MB)))
-		podScore[key] = pairedScore
-	}
+// SortByLeastRequests migrates the pods requesting the least cpu, memory and extended resources first
+const SortByLeastRequests = "LeastRequests"
 
-	sort.Slice(keys, func(i, j int) bool { return podScore[keys[i]] < podScore[keys[j]] })
+func init() {
+	framework.RegisterSorter(SortByLeastRequests, compareRequests)
+}
 
-	var sortPods []*capacity.PodInfo
-	for _, key := range keys {
-		strs := strings.Split(key, "-")
-		if len(strs) < 2 {
-			continue
-		}
-		ns := strs[0]
-		name := strs[1]
-		for _ , pod := range pods {
-			if pod.Pod.GetNamespace() == ns && pod.Pod.GetName() == name {
-				sortPods = append(sortPods, pod)
-			}
-		}
+// compareRequests orders the pods by the pairing of their cpu, memory and extended resources requests
+func compareRequests(a, b *v1.Pod) int {
+	return requestsScore(a) - requestsScore(b)
+}
+
+func requestsScore(pod *v1.Pod) int {
+	var pairedScore int
+	res, cpu, mem := capacity.CalculateResource(pod)
+	for _, rQuant := range res.ScalarResources {
+		pairedScore += cantor.Pair(int(math.Abs(float64(rQuant))), int(math.Abs(float64(cpu))))
+		pairedScore += cantor.Pair(int(math.Abs(float64(rQuant))), int(math.Abs(float64(mem)/capacity.MB)))
 	}
+	pairedScore += cantor.Pair(int(math.Abs(float64(cpu))), int(math.Abs(float64(mem)/capacity.MB)))
+	return pairedScore
+}
 
-	return sortPods
+// sortMigrateablePods orders the pods to migrate with the sorters of the strategy
+func sortMigrateablePods(pods []*capacity.PodInfo, sortPods func([]*v1.Pod))[]*capacity.PodInfo{
+	podInfos := make(map[*v1.Pod]*capacity.PodInfo, len(pods))
+	sorted := make([]*v1.Pod, 0, len(pods))
+	for _, podInfo := range pods {
+		podInfos[podInfo.Pod] = podInfo
+		sorted = append(sorted, podInfo.Pod)
+	}
+	sortPods(sorted)
+
+	sortPodInfos := make([]*capacity.PodInfo, 0, len(sorted))
+	for _, pod := range sorted {
+		sortPodInfos = append(sortPodInfos, podInfos[pod])
+	}
+	return sortPodInfos
 }
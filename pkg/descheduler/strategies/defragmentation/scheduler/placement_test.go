@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation/capacity"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSortMigrateablePods(t *testing.T) {
+	pods := []*capacity.PodInfo{
+		capacity.NewPodInfo(test.BuildTestPod("large", 800, 2*capacity.MB*1024, "n1", nil)),
+		capacity.NewPodInfo(test.BuildTestPod("small", 100, capacity.MB*128, "n1", nil)),
+		capacity.NewPodInfo(test.BuildTestPod("medium", 400, capacity.MB*512, "n1", nil)),
+	}
+
+	tests := []struct {
+		description string
+		sortBy      []string
+		expected    []string
+	}{
+		{
+			description: "pods requesting the least resources first",
+			sortBy:      []string{SortByLeastRequests},
+			expected:    []string{"small", "medium", "large"},
+		},
+		{
+			description: "pods in the order they are listed without sorter",
+			expected:    []string{"large", "small", "medium"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			sortPods, err := framework.NewSorter(tc.sortBy...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var sorted []string
+			for _, podInfo := range sortMigrateablePods(pods, sortPods) {
+				sorted = append(sorted, podInfo.Pod.Name)
+			}
+			if !reflect.DeepEqual(sorted, tc.expected) {
+				t.Errorf("Expected pods %v, got %v", tc.expected, sorted)
+			}
+		})
+	}
+}
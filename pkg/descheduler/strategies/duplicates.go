@@ -27,11 +27,13 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
@@ -56,6 +58,22 @@ type podOwner struct {
 	imagesHash            string
 }
 
+// duplicatePods holds the parameters of RemoveDuplicates
+type duplicatePods struct {
+	excludeOwnerKinds sets.String
+}
+
+func newDuplicatePods(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateRemoveDuplicatePodsParams(params); err != nil {
+		return nil, err
+	}
+	d := &duplicatePods{}
+	if params != nil && params.RemoveDuplicates != nil {
+		d.excludeOwnerKinds = sets.NewString(params.RemoveDuplicates.ExcludeOwnerKinds...)
+	}
+	return d, nil
+}
+
 // RemoveDuplicatePods removes the duplicate pods on node. This strategy evicts all duplicate pods on node.
 // A pod is said to be a duplicate of other if both of them are from same creator, kind and are within the same
 // namespace, and have at least one container with the same image.
@@ -66,30 +84,12 @@ func RemoveDuplicatePods(
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
 ) {
-	if err := validateRemoveDuplicatePodsParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemoveDuplicatePods parameters")
-		return
-	}
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
-	}
-
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params != nil && strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
-	}
-
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
-	}
-
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+	framework.NewStrategy("RemoveDuplicates", newDuplicatePods)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
+func (d *duplicatePods) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
 	duplicatePods := make(map[podOwner]map[string][]*v1.Pod)
 	ownerKeyOccurence := make(map[podOwner]int32)
 	nodeCount := 0
@@ -97,13 +97,7 @@ func RemoveDuplicatePods(
 
 	for _, node := range nodes {
 		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListPodsOnANode(ctx,
-			client,
-			node,
-			podutil.WithFilter(evictable.IsEvictable),
-			podutil.WithNamespaces(includedNamespaces),
-			podutil.WithoutNamespaces(excludedNamespaces),
-		)
+		pods, err := h.ListPods(ctx, node, h.PreFilter)
 		if err != nil {
 			klog.ErrorS(err, "Error listing evictable pods on node", "node", klog.KObj(node))
 			continue
@@ -123,10 +117,14 @@ func RemoveDuplicatePods(
 		// the same first entry is clearly not a duplicate. This makes lookup quick and minimizes storage needed).
 		// If any of the existing lists for that first key matches the current pod's list, the current pod is a duplicate.
 		// If not, then we add this pod's list to the list of lists for that key.
+		//
+		// The pods are walked from the last to be evicted, in the order of the sorters of the strategy, so that the
+		// duplicates to be evicted are at the end of the lists of duplicate pods.
 		duplicateKeysMap := map[string][][]string{}
-		for _, pod := range pods {
+		for i := len(pods) - 1; i >= 0; i-- {
+			pod := pods[i]
 			ownerRefList := podutil.OwnerRef(pod)
-			if hasExcludedOwnerRefKind(ownerRefList, strategy) || len(ownerRefList) == 0 {
+			if d.hasExcludedOwnerRefKind(ownerRefList) || len(ownerRefList) == 0 {
 				continue
 			}
 			podContainerKeys := make([]string, 0, len(ownerRefList)*len(pod.Spec.Containers))
@@ -205,7 +203,10 @@ func RemoveDuplicatePods(
 				// It's assumed all duplicated pods are in the same priority class
 				// TODO(jchaloup): check if the pod has a different node to lend to
 				for _, pod := range pods[upperAvg-1:] {
-					if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[nodeName], "RemoveDuplicatePods"); err != nil {
+					if _, err := h.PodEvictor.EvictPod(ctx, pod, nodeMap[nodeName], "RemoveDuplicatePods"); err != nil {
+						if evictions.IsNamespaceLimitReached(err) {
+							continue
+						}
 						klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 						break
 					}
@@ -282,13 +283,9 @@ func getTargetNodes(podNodes map[string][]*v1.Pod, nodes []*v1.Node) []*v1.Node
 	return targetNodes
 }
 
-func hasExcludedOwnerRefKind(ownerRefs []metav1.OwnerReference, strategy api.DeschedulerStrategy) bool {
-	if strategy.Params == nil || strategy.Params.RemoveDuplicates == nil {
-		return false
-	}
-	exclude := sets.NewString(strategy.Params.RemoveDuplicates.ExcludeOwnerKinds...)
+func (d *duplicatePods) hasExcludedOwnerRefKind(ownerRefs []metav1.OwnerReference) bool {
 	for _, owner := range ownerRefs {
-		if exclude.Has(owner.Kind) {
+		if d.excludeOwnerKinds.Has(owner.Kind) {
 			return true
 		}
 	}
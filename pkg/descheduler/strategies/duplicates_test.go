@@ -288,13 +288,15 @@ func TestFindDuplicatePods(t *testing.T) {
 				"v1",
 				false,
 				testCase.maxPodsToEvictPerNode,
+				0,
+				0,
 				testCase.nodes,
 				false,
 				false,
 				false,
 			)
 
-			RemoveDuplicatePods(ctx, fakeClient, testCase.strategy, testCase.nodes, podEvictor)
+			RemoveDuplicatePods(ctx, fakeClient, testCase.strategy, testCase.nodes, podEvictor, nil)
 			podsEvicted := podEvictor.TotalEvicted()
 			if podsEvicted != testCase.expectedEvictedPodCount {
 				t.Errorf("Test error for description: %s. Expected evicted pods count %v, got %v", testCase.description, testCase.expectedEvictedPodCount, podsEvicted)
@@ -696,13 +698,15 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				testCase.maxPodsToEvictPerNode,
+				0,
+				0,
 				testCase.nodes,
 				false,
 				false,
 				false,
 			)
 
-			RemoveDuplicatePods(ctx, fakeClient, testCase.strategy, testCase.nodes, podEvictor)
+			RemoveDuplicatePods(ctx, fakeClient, testCase.strategy, testCase.nodes, podEvictor, nil)
 			podsEvicted := podEvictor.TotalEvicted()
 			if podsEvicted != testCase.expectedEvictedPodCount {
 				t.Errorf("Test error for description: %s. Expected evicted pods count %v, got %v", testCase.description, testCase.expectedEvictedPodCount, podsEvicted)
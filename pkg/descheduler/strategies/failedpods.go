@@ -8,24 +8,35 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 )
 
-// validatedFailedPodsStrategyParams contains validated strategy parameters
-type validatedFailedPodsStrategyParams struct {
-	validation.ValidatedStrategyParams
+// failedPods holds the FailedPods parameters of RemoveFailedPods
+type failedPods struct {
 	includingInitContainers bool
 	reasons                 sets.String
 	excludeOwnerKinds       sets.String
 	minPodLifetimeSeconds   *uint
 }
 
+func newFailedPods(params *api.StrategyParameters) (framework.Plugin, error) {
+	d := &failedPods{}
+	if params != nil && params.FailedPods != nil {
+		d.reasons = sets.NewString(params.FailedPods.Reasons...)
+		d.includingInitContainers = params.FailedPods.IncludingInitContainers
+		d.excludeOwnerKinds = sets.NewString(params.FailedPods.ExcludeOwnerKinds...)
+		d.minPodLifetimeSeconds = params.FailedPods.MinPodLifetimeSeconds
+	}
+	return d, nil
+}
+
 // RemoveFailedPods removes Pods that are in failed status phase.
 func RemoveFailedPods(
 	ctx context.Context,
@@ -33,95 +44,38 @@ func RemoveFailedPods(
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
 ) {
-	strategyParams, err := validateAndParseRemoveFailedPodsParams(ctx, client, strategy.Params)
+	framework.NewStrategy("RemoveFailedPods", newFailedPods)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
+
+func (d *failedPods) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	fieldSelectorString := "spec.nodeName=" + node.Name + ",status.phase=" + string(v1.PodFailed)
+	pods, err := h.ListPodsWithFieldSelector(ctx, node, fieldSelectorString, h.PreFilter)
 	if err != nil {
-		klog.ErrorS(err, "Invalid RemoveFailedPods parameters")
+		klog.ErrorS(err, "Error listing a nodes failed pods", "node", klog.KObj(node))
 		return
 	}
 
-	evictable := podEvictor.Evictable(
-		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
-		evictions.WithNodeFit(strategyParams.NodeFit),
-		evictions.WithLabelSelector(strategyParams.LabelSelector),
-	)
-
-	var labelSelector *metav1.LabelSelector
-	if strategy.Params != nil {
-		labelSelector = strategy.Params.LabelSelector
-	}
-
-	for _, node := range nodes {
-		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		fieldSelectorString := "spec.nodeName=" + node.Name + ",status.phase=" + string(v1.PodFailed)
-
-		pods, err := podutil.ListPodsOnANodeWithFieldSelector(
-			ctx,
-			client,
-			node,
-			fieldSelectorString,
-			podutil.WithFilter(evictable.IsEvictable),
-			podutil.WithNamespaces(strategyParams.IncludedNamespaces.UnsortedList()),
-			podutil.WithoutNamespaces(strategyParams.ExcludedNamespaces.UnsortedList()),
-			podutil.WithLabelSelector(labelSelector),
-		)
-		if err != nil {
-			klog.ErrorS(err, "Error listing a nodes failed pods", "node", klog.KObj(node))
+	for i, pod := range pods {
+		if err = validateFailedPodShouldEvict(pod, d); err != nil {
+			klog.V(4).InfoS(fmt.Sprintf("ignoring pod for eviction due to: %s", err.Error()), "pod", klog.KObj(pod))
 			continue
 		}
 
-		for i, pod := range pods {
-			if err = validateFailedPodShouldEvict(pod, *strategyParams); err != nil {
-				klog.V(4).InfoS(fmt.Sprintf("ignoring pod for eviction due to: %s", err.Error()), "pod", klog.KObj(pod))
+		if _, err = h.PodEvictor.EvictPod(ctx, pods[i], node, "FailedPod"); err != nil {
+			if evictions.IsNamespaceLimitReached(err) {
 				continue
 			}
-
-			if _, err = podEvictor.EvictPod(ctx, pods[i], node, "FailedPod"); err != nil {
-				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
-				break
-			}
+			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+			break
 		}
 	}
 }
 
-func validateAndParseRemoveFailedPodsParams(
-	ctx context.Context,
-	client clientset.Interface,
-	params *api.StrategyParameters,
-) (*validatedFailedPodsStrategyParams, error) {
-	if params == nil {
-		return &validatedFailedPodsStrategyParams{
-			ValidatedStrategyParams: validation.DefaultValidatedStrategyParams(),
-		}, nil
-	}
-
-	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, params)
-	if err != nil {
-		return nil, err
-	}
-
-	var reasons, excludeOwnerKinds sets.String
-	var includingInitContainers bool
-	var minPodLifetimeSeconds *uint
-	if params.FailedPods != nil {
-		reasons = sets.NewString(params.FailedPods.Reasons...)
-		includingInitContainers = params.FailedPods.IncludingInitContainers
-		excludeOwnerKinds = sets.NewString(params.FailedPods.ExcludeOwnerKinds...)
-		minPodLifetimeSeconds = params.FailedPods.MinPodLifetimeSeconds
-	}
-
-	return &validatedFailedPodsStrategyParams{
-		ValidatedStrategyParams: *strategyParams,
-		includingInitContainers: includingInitContainers,
-		reasons:                 reasons,
-		excludeOwnerKinds:       excludeOwnerKinds,
-		minPodLifetimeSeconds:   minPodLifetimeSeconds,
-	}, nil
-}
-
 // validateFailedPodShouldEvict looks at strategy params settings to see if the Pod
 // should be evicted given the params in the PodFailed policy.
-func validateFailedPodShouldEvict(pod *v1.Pod, strategyParams validatedFailedPodsStrategyParams) error {
+func validateFailedPodShouldEvict(pod *v1.Pod, strategyParams *failedPods) error {
 	var errs []error
 
 	if strategyParams.minPodLifetimeSeconds != nil {
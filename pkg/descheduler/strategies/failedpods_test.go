@@ -210,13 +210,15 @@ func TestRemoveFailedPods(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			100,
+			0,
+			0,
 			tc.nodes,
 			false,
 			false,
 			false,
 		)
 
-		RemoveFailedPods(ctx, fakeClient, tc.strategy, tc.nodes, podEvictor)
+		RemoveFailedPods(ctx, fakeClient, tc.strategy, tc.nodes, podEvictor, nil)
 		actualEvictedPodCount := podEvictor.TotalEvicted()
 		if actualEvictedPodCount != tc.expectedEvictedPodCount {
 			t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedPodCount, actualEvictedPodCount)
@@ -225,8 +227,6 @@ func TestRemoveFailedPods(t *testing.T) {
 }
 
 func TestValidRemoveFailedPodsParams(t *testing.T) {
-	ctx := context.Background()
-	fakeClient := &fake.Clientset{}
 	testCases := []struct {
 		name   string
 		params *api.StrategyParameters
@@ -247,14 +247,15 @@ func TestValidRemoveFailedPodsParams(t *testing.T) {
 		}}},
 	}
 	for _, tc := range testCases {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			params, err := validateAndParseRemoveFailedPodsParams(ctx, fakeClient, tc.params)
+			plugin, err := newFailedPods(tc.params)
 			if err != nil {
 				t.Errorf("strategy params should be valid but got err: %v", err.Error())
 			}
-			if params == nil {
-				t.Errorf("strategy params should return a ValidatedFailedPodsStrategyParams but got nil")
+			if plugin == nil {
+				t.Errorf("strategy params should return a failedPods plugin but got nil")
 			}
 		})
 	}
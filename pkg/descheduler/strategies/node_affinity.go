@@ -21,14 +21,14 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
-	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
 func validatePodsViolatingNodeAffinityParams(params *api.StrategyParameters) error {
@@ -46,63 +46,46 @@ func validatePodsViolatingNodeAffinityParams(params *api.StrategyParameters) err
 	return nil
 }
 
-// RemovePodsViolatingNodeAffinity evicts pods on nodes which violate node affinity
-func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validatePodsViolatingNodeAffinityParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeAffinity parameters")
-		return
-	}
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
-	}
-
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
-	}
+type podsViolatingNodeAffinity struct {
+	nodeAffinityTypes []string
+}
 
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
+func newPodsViolatingNodeAffinity(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validatePodsViolatingNodeAffinityParams(params); err != nil {
+		return nil, err
 	}
+	return &podsViolatingNodeAffinity{nodeAffinityTypes: params.NodeAffinityType}, nil
+}
 
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
+// RemovePodsViolatingNodeAffinity evicts pods on nodes which violate node affinity
+func RemovePodsViolatingNodeAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy("RemovePodsViolatingNodeAffinity", newPodsViolatingNodeAffinity)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
-	for _, nodeAffinity := range strategy.Params.NodeAffinityType {
+func (d *podsViolatingNodeAffinity) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	for _, nodeAffinity := range d.nodeAffinityTypes {
 		klog.V(2).InfoS("Executing for nodeAffinityType", "nodeAffinity", nodeAffinity)
 
 		switch nodeAffinity {
 		case "requiredDuringSchedulingIgnoredDuringExecution":
-			for _, node := range nodes {
-				klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-
-				pods, err := podutil.ListPodsOnANode(
-					ctx,
-					client,
-					node,
-					podutil.WithFilter(func(pod *v1.Pod) bool {
-						return evictable.IsEvictable(pod) &&
-							!nodeutil.PodFitsCurrentNode(pod, node) &&
-							nodeutil.PodFitsAnyNode(pod, nodes)
-					}),
-					podutil.WithNamespaces(includedNamespaces),
-					podutil.WithoutNamespaces(excludedNamespaces),
-					podutil.WithLabelSelector(strategy.Params.LabelSelector),
-				)
-				if err != nil {
-					klog.ErrorS(err, "Failed to get pods", "node", klog.KObj(node))
-				}
+			pods, err := h.ListPods(ctx, node, func(pod *v1.Pod) bool {
+				return h.PreFilter(pod) &&
+					!nodeutil.PodFitsCurrentNode(pod, node) &&
+					nodeutil.PodFitsAnyNode(pod, h.Nodes)
+			})
+			if err != nil {
+				klog.ErrorS(err, "Failed to get pods", "node", klog.KObj(node))
+			}
 
-				for _, pod := range pods {
-					if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil && pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-						klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
-						if _, err := podEvictor.EvictPod(ctx, pod, node, "NodeAffinity"); err != nil {
-							klog.ErrorS(err, "Error evicting pod")
-							break
+			for _, pod := range pods {
+				if pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil && pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+					klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
+					if _, err := h.PodEvictor.EvictPod(ctx, pod, node, "NodeAffinity"); err != nil {
+						if evictions.IsNamespaceLimitReached(err) {
+							continue
 						}
+						klog.ErrorS(err, "Error evicting pod")
+						break
 					}
 				}
 			}
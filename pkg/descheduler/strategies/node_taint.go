@@ -22,11 +22,11 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
-	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -47,63 +47,41 @@ func validateRemovePodsViolatingNodeTaintsParams(params *api.StrategyParameters)
 	return nil
 }
 
-// RemovePodsViolatingNodeTaints evicts pods on the node which violate NoSchedule Taints on nodes
-func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsViolatingNodeTaintsParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingNodeTaints parameters")
-		return
-	}
+type podsViolatingNodeTaints struct{}
 
-	var includedNamespaces, excludedNamespaces []string
-	var labelSelector *metav1.LabelSelector
-	if strategy.Params != nil {
-		if strategy.Params.Namespaces != nil {
-			includedNamespaces = strategy.Params.Namespaces.Include
-			excludedNamespaces = strategy.Params.Namespaces.Exclude
-		}
-		labelSelector = strategy.Params.LabelSelector
+func newPodsViolatingNodeTaints(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateRemovePodsViolatingNodeTaintsParams(params); err != nil {
+		return nil, err
 	}
+	return &podsViolatingNodeTaints{}, nil
+}
 
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
+// RemovePodsViolatingNodeTaints evicts pods on the node which violate NoSchedule Taints on nodes
+func RemovePodsViolatingNodeTaints(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy("RemovePodsViolatingNodeTaints", newPodsViolatingNodeTaints)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
+
+func (d *podsViolatingNodeTaints) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	pods, err := h.ListPods(ctx, node, h.PreFilter)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
+		//no pods evicted as error encountered retrieving evictable Pods
+		klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
 		return
 	}
-
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
-	}
-
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
-
-	for _, node := range nodes {
-		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListPodsOnANode(
-			ctx,
-			client,
-			node,
-			podutil.WithFilter(evictable.IsEvictable),
-			podutil.WithNamespaces(includedNamespaces),
-			podutil.WithoutNamespaces(excludedNamespaces),
-			podutil.WithLabelSelector(labelSelector),
-		)
-		if err != nil {
-			//no pods evicted as error encountered retrieving evictable Pods
-			return
-		}
-		totalPods := len(pods)
-		for i := 0; i < totalPods; i++ {
-			if !utils.TolerationsTolerateTaintsWithFilter(
-				pods[i].Spec.Tolerations,
-				node.Spec.Taints,
-				func(taint *v1.Taint) bool { return taint.Effect == v1.TaintEffectNoSchedule },
-			) {
-				klog.V(2).InfoS("Not all taints with NoSchedule effect are tolerated after update for pod on node", "pod", klog.KObj(pods[i]), "node", klog.KObj(node))
-				if _, err := podEvictor.EvictPod(ctx, pods[i], node, "NodeTaint"); err != nil {
-					klog.ErrorS(err, "Error evicting pod")
-					break
+	totalPods := len(pods)
+	for i := 0; i < totalPods; i++ {
+		if !utils.TolerationsTolerateTaintsWithFilter(
+			pods[i].Spec.Tolerations,
+			node.Spec.Taints,
+			func(taint *v1.Taint) bool { return taint.Effect == v1.TaintEffectNoSchedule },
+		) {
+			klog.V(2).InfoS("Not all taints with NoSchedule effect are tolerated after update for pod on node", "pod", klog.KObj(pods[i]), "node", klog.KObj(node))
+			if _, err := h.PodEvictor.EvictPod(ctx, pods[i], node, "NodeTaint"); err != nil {
+				if evictions.IsNamespaceLimitReached(err) {
+					continue
 				}
+				klog.ErrorS(err, "Error evicting pod")
+				break
 			}
 		}
 	}
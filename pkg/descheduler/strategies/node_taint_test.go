@@ -235,6 +235,8 @@ func TestDeletePodsViolatingNodeTaints(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			0,
+			0,
 			tc.nodes,
 			tc.evictLocalStoragePods,
 			tc.evictSystemCriticalPods,
@@ -247,7 +249,7 @@ func TestDeletePodsViolatingNodeTaints(t *testing.T) {
 			},
 		}
 
-		RemovePodsViolatingNodeTaints(ctx, fakeClient, strategy, tc.nodes, podEvictor)
+		RemovePodsViolatingNodeTaints(ctx, fakeClient, strategy, tc.nodes, podEvictor, nil)
 		actualEvictedPodCount := podEvictor.TotalEvicted()
 		if actualEvictedPodCount != tc.expectedEvictedPodCount {
 			t.Errorf("Test %#v failed, Unexpected no of pods evicted: pods evicted: %d, expected: %d", tc.description, actualEvictedPodCount, tc.expectedEvictedPodCount)
@@ -22,46 +22,57 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-// HighNodeUtilization evicts pods from under utilized nodes so that scheduler can schedule according to its strategy.
-// Note that CPU/Memory requests are used to calculate nodes' utilization and not the actual resource usage.
-func HighNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateNodeUtilizationParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid HighNodeUtilization parameters")
-		return
-	}
+type highNodeUtilization struct {
+	params  *api.NodeResourceUtilizationThresholds
+	history *usageHistory
+}
 
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
+func newHighNodeUtilization(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateNodeUtilizationParams(params); err != nil {
+		return nil, err
 	}
-
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+	thresholds := params.NodeResourceUtilizationThresholds
+	if err := validateHighUtilizationStrategyConfig(thresholds.Thresholds, thresholds.TargetThresholds); err != nil {
+		return nil, err
 	}
+	return &highNodeUtilization{params: thresholds, history: highNodeUtilizationHistory}, nil
+}
 
-	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds
-	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds
-	if err := validateHighUtilizationStrategyConfig(thresholds, targetThresholds); err != nil {
-		klog.ErrorS(err, "HighNodeUtilization config is not valid")
-		return
-	}
-	targetThresholds = make(api.ResourceThresholds)
+// HighNodeUtilization evicts pods from under utilized nodes so that scheduler can schedule according to its strategy.
+// Note that CPU/Memory requests are used to calculate nodes' utilization and not the actual resource usage,
+// unless the metrics usage source is configured.
+func HighNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy(
+		"HighNodeUtilization",
+		newHighNodeUtilization,
+		framework.WithDefaultSortBy(framework.SortByPriority),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
+
+func (u *highNodeUtilization) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
+	thresholds := u.params.Thresholds
+	targetThresholds := make(api.ResourceThresholds)
 
 	setDefaultForThresholds(thresholds, targetThresholds)
 	resourceNames := getResourceNames(targetThresholds)
 
+	usageClient, err := newUsageClient(ctx, h.Client, h.PodEvictor.MetricsClient(), h.PodInformer, u.params, u.history)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read the usage of the nodes")
+		return
+	}
+
 	sourceNodes, highNodes := classifyNodes(
-		getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames),
+		getNodeUsage(ctx, h.Client, nodes, thresholds, targetThresholds, false, resourceNames, h.PodInformer, usageClient),
 		func(node *v1.Node, usage NodeUsage) bool {
 			return isNodeWithLowUtilization(usage)
 		},
@@ -92,8 +103,8 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
 		return
 	}
-	if len(sourceNodes) <= strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes)
+	if len(sourceNodes) <= u.params.NumberOfNodes {
+		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", u.params.NumberOfNodes)
 		return
 	}
 	if len(sourceNodes) == len(nodes) {
@@ -105,8 +116,6 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 		return
 	}
 
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
-
 	// stop if the total available usage has dropped to zero - no more pods can be scheduled
 	continueEvictionCond := func(nodeUsage NodeUsage, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
 		for name := range totalAvailableUsage {
@@ -121,9 +130,11 @@ func HighNodeUtilization(ctx context.Context, client clientset.Interface, strate
 		ctx,
 		sourceNodes,
 		highNodes,
-		podEvictor,
-		evictable.IsEvictable,
+		h.PodEvictor,
+		h.PreFilter,
+		h.Sort,
 		resourceNames,
+		usageClient,
 		"HighNodeUtilization",
 		continueEvictionCond)
 
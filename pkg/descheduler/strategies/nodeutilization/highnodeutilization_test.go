@@ -578,6 +578,8 @@ func TestHighNodeUtilization(t *testing.T) {
 				"v1",
 				false,
 				test.maxPodsToEvictPerNode,
+				0,
+				0,
 				nodes,
 				false,
 				false,
@@ -593,7 +595,7 @@ func TestHighNodeUtilization(t *testing.T) {
 					NodeFit: true,
 				},
 			}
-			HighNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+			HighNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor, nil)
 
 			podsEvicted := podEvictor.TotalEvicted()
 			if test.expectedPodsEvicted != podsEvicted {
@@ -768,13 +770,15 @@ func TestHighNodeUtilizationWithTaints(t *testing.T) {
 				"policy/v1",
 				false,
 				item.evictionsExpected,
+				0,
+				0,
 				item.nodes,
 				false,
 				false,
 				false,
 			)
 
-			HighNodeUtilization(ctx, fakeClient, strategy, item.nodes, podEvictor)
+			HighNodeUtilization(ctx, fakeClient, strategy, item.nodes, podEvictor, nil)
 
 			if item.evictionsExpected != podEvictor.TotalEvicted() {
 				t.Errorf("Expected %v evictions, got %v", item.evictionsExpected, podEvictor.TotalEvicted())
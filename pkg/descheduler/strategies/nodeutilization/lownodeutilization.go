@@ -22,40 +22,45 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-// LowNodeUtilization evicts pods from overutilized nodes to underutilized nodes. Note that CPU/Memory requests are used
-// to calculate nodes' utilization and not the actual resource usage.
-func LowNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	// TODO: May be create a struct for the strategy as well, so that we don't have to pass along the all the params?
-	if err := validateNodeUtilizationParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid LowNodeUtilization parameters")
-		return
+type lowNodeUtilization struct {
+	params  *api.NodeResourceUtilizationThresholds
+	history *usageHistory
+}
+
+func newLowNodeUtilization(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateNodeUtilizationParams(params); err != nil {
+		return nil, err
 	}
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
-		return
+	thresholds := params.NodeResourceUtilizationThresholds
+	if err := validateLowUtilizationStrategyConfig(thresholds.Thresholds, thresholds.TargetThresholds, thresholds.UseDeviationThresholds); err != nil {
+		return nil, err
 	}
+	return &lowNodeUtilization{params: thresholds, history: lowNodeUtilizationHistory}, nil
+}
 
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
-	}
+// LowNodeUtilization evicts pods from overutilized nodes to underutilized nodes. Note that CPU/Memory requests are used
+// to calculate nodes' utilization and not the actual resource usage, unless the metrics usage source is configured.
+func LowNodeUtilization(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy(
+		"LowNodeUtilization",
+		newLowNodeUtilization,
+		framework.WithDefaultSortBy(framework.SortByPriority),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
-	thresholds := strategy.Params.NodeResourceUtilizationThresholds.Thresholds
-	targetThresholds := strategy.Params.NodeResourceUtilizationThresholds.TargetThresholds
-	if err := validateLowUtilizationStrategyConfig(thresholds, targetThresholds); err != nil {
-		klog.ErrorS(err, "LowNodeUtilization config is not valid")
-		return
-	}
+func (l *lowNodeUtilization) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
+	thresholds := l.params.Thresholds
+	targetThresholds := l.params.TargetThresholds
 	// check if Pods/CPU/Mem are set, if not, set them to 100
 	if _, ok := thresholds[v1.ResourcePods]; !ok {
 		thresholds[v1.ResourcePods] = MaxResourcePercentage
@@ -71,8 +76,14 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 	}
 	resourceNames := getResourceNames(thresholds)
 
+	usageClient, err := newUsageClient(ctx, h.Client, h.PodEvictor.MetricsClient(), h.PodInformer, l.params, l.history)
+	if err != nil {
+		klog.ErrorS(err, "Unable to read the usage of the nodes")
+		return
+	}
+
 	lowNodes, sourceNodes := classifyNodes(
-		getNodeUsage(ctx, client, nodes, thresholds, targetThresholds, resourceNames),
+		getNodeUsage(ctx, h.Client, nodes, thresholds, targetThresholds, l.params.UseDeviationThresholds, resourceNames, h.PodInformer, usageClient),
 		// The node has to be schedulable (to be able to move workload there)
 		func(node *v1.Node, usage NodeUsage) bool {
 			if nodeutil.IsNodeUnschedulable(node) {
@@ -119,8 +130,8 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		return
 	}
 
-	if len(lowNodes) <= strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", strategy.Params.NodeResourceUtilizationThresholds.NumberOfNodes)
+	if len(lowNodes) <= l.params.NumberOfNodes {
+		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", l.params.NumberOfNodes)
 		return
 	}
 
@@ -134,8 +145,6 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		return
 	}
 
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
-
 	// stop if node utilization drops below target threshold or any of required capacity (cpu, memory, pods) is moved
 	continueEvictionCond := func(nodeUsage NodeUsage, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
 		if !isNodeAboveTargetUtilization(nodeUsage) {
@@ -154,17 +163,20 @@ func LowNodeUtilization(ctx context.Context, client clientset.Interface, strateg
 		ctx,
 		sourceNodes,
 		lowNodes,
-		podEvictor,
-		evictable.IsEvictable,
+		h.PodEvictor,
+		h.PreFilter,
+		h.Sort,
 		resourceNames,
+		usageClient,
 		"LowNodeUtilization",
 		continueEvictionCond)
 
-	klog.V(1).InfoS("Total number of pods evicted", "evictedPods", podEvictor.TotalEvicted())
+	klog.V(1).InfoS("Total number of pods evicted", "evictedPods", h.PodEvictor.TotalEvicted())
 }
 
-// validateLowUtilizationStrategyConfig checks if the strategy's config is valid
-func validateLowUtilizationStrategyConfig(thresholds, targetThresholds api.ResourceThresholds) error {
+// validateLowUtilizationStrategyConfig checks if the strategy's config is valid. As deviations from the average
+// utilization, thresholds can be greater than targetThresholds.
+func validateLowUtilizationStrategyConfig(thresholds, targetThresholds api.ResourceThresholds, useDeviationThresholds bool) error {
 	// validate thresholds and targetThresholds config
 	if err := validateThresholds(thresholds); err != nil {
 		return fmt.Errorf("thresholds config is not valid: %v", err)
@@ -180,7 +192,7 @@ func validateLowUtilizationStrategyConfig(thresholds, targetThresholds api.Resou
 	for resourceName, value := range thresholds {
 		if targetValue, ok := targetThresholds[resourceName]; !ok {
 			return fmt.Errorf("thresholds and targetThresholds configured different resources")
-		} else if value > targetValue {
+		} else if value > targetValue && !useDeviationThresholds {
 			return fmt.Errorf("thresholds' %v percentage is greater than targetThresholds'", resourceName)
 		}
 	}
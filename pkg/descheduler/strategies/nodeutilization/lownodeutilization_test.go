@@ -27,8 +27,10 @@ import (
 	"k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -789,6 +791,8 @@ func TestLowNodeUtilization(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				test.maxPodsToEvictPerNode,
+				0,
+				0,
 				nodes,
 				false,
 				false,
@@ -805,7 +809,7 @@ func TestLowNodeUtilization(t *testing.T) {
 					NodeFit: true,
 				},
 			}
-			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor)
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor, nil)
 
 			podsEvicted := podEvictor.TotalEvicted()
 			if test.expectedPodsEvicted != podsEvicted {
@@ -820,10 +824,11 @@ func TestLowNodeUtilization(t *testing.T) {
 
 func TestValidateLowNodeUtilizationStrategyConfig(t *testing.T) {
 	tests := []struct {
-		name             string
-		thresholds       api.ResourceThresholds
-		targetThresholds api.ResourceThresholds
-		errInfo          error
+		name                   string
+		thresholds             api.ResourceThresholds
+		targetThresholds       api.ResourceThresholds
+		useDeviationThresholds bool
+		errInfo                error
 	}{
 		{
 			name: "passing invalid thresholds",
@@ -875,6 +880,19 @@ func TestValidateLowNodeUtilizationStrategyConfig(t *testing.T) {
 			},
 			errInfo: fmt.Errorf("thresholds' %v percentage is greater than targetThresholds'", v1.ResourceCPU),
 		},
+		{
+			name: "thresholds' CPU deviation is greater than targetThresholds'",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    90,
+				v1.ResourceMemory: 20,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    80,
+				v1.ResourceMemory: 80,
+			},
+			useDeviationThresholds: true,
+			errInfo:                nil,
+		},
 		{
 			name: "only thresholds configured extended resource",
 			thresholds: api.ResourceThresholds{
@@ -958,7 +976,7 @@ func TestValidateLowNodeUtilizationStrategyConfig(t *testing.T) {
 	}
 
 	for _, testCase := range tests {
-		validateErr := validateLowUtilizationStrategyConfig(testCase.thresholds, testCase.targetThresholds)
+		validateErr := validateLowUtilizationStrategyConfig(testCase.thresholds, testCase.targetThresholds, testCase.useDeviationThresholds)
 
 		if validateErr == nil || testCase.errInfo == nil {
 			if validateErr != testCase.errInfo {
@@ -1089,13 +1107,207 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 				policyv1.SchemeGroupVersion.String(),
 				false,
 				item.evictionsExpected,
+				0,
+				0,
 				item.nodes,
 				false,
 				false,
 				false,
 			)
 
-			LowNodeUtilization(ctx, fakeClient, strategy, item.nodes, podEvictor)
+			LowNodeUtilization(ctx, fakeClient, strategy, item.nodes, podEvictor, nil)
+
+			if item.evictionsExpected != podEvictor.TotalEvicted() {
+				t.Errorf("Expected %v evictions, got %v", item.evictionsExpected, podEvictor.TotalEvicted())
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 4000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+
+	// the pods request little CPU, the nodes looking underutilized by their requests
+	var pods []*v1.Pod
+	for i := 1; i <= 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("pod_%d_%s", i, n1.Name), 100, 0, n1.Name, test.SetRSOwnerRef))
+	}
+	pods = append(pods, test.BuildTestPod(fmt.Sprintf("pod_5_%s", n2.Name), 100, 0, n2.Name, test.SetRSOwnerRef))
+	for _, pod := range pods {
+		pod.UID = types.UID(pod.Name)
+	}
+
+	tests := []struct {
+		name              string
+		usageSource       string
+		metricsClient     bool
+		podMetrics        bool
+		evictionsExpected int
+	}{
+		{
+			name:              "Requests usage source",
+			usageSource:       UsageSourceRequests,
+			metricsClient:     true,
+			podMetrics:        true,
+			evictionsExpected: 0,
+		},
+		{
+			name:              "Metrics usage source",
+			usageSource:       UsageSourceMetrics,
+			metricsClient:     true,
+			podMetrics:        true,
+			evictionsExpected: 2,
+		},
+		{
+			// the requests of the evicted pods are accounted for, all of them being evicted
+			name:              "Metrics usage source without pod metrics",
+			usageSource:       UsageSourceMetrics,
+			metricsClient:     true,
+			evictionsExpected: 4,
+		},
+		{
+			name:              "Metrics usage source without metrics client",
+			usageSource:       UsageSourceMetrics,
+			evictionsExpected: 0,
+		},
+	}
+
+	for _, item := range tests {
+		t.Run(item.name, func(t *testing.T) {
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			var opts []evictions.EvictorOption
+			if item.metricsClient {
+				metricsClient := metricsfake.NewSimpleClientset()
+				// n1 is overutilized with 90% of its CPU used, n2 and n3 underutilized with 12.5%
+				addNodeMetrics(t, metricsClient, n1.Name, 3600)
+				addNodeMetrics(t, metricsClient, n2.Name, 500)
+				addNodeMetrics(t, metricsClient, n3.Name, 500)
+				if item.podMetrics {
+					for _, pod := range pods {
+						addPodMetrics(t, metricsClient, pod, 800)
+					}
+				}
+				opts = append(opts, evictions.WithMetricsClient(metricsClient))
+			}
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+				opts...,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:       api.ResourceThresholds{v1.ResourceCPU: 20},
+						TargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+						UsageSource:      item.usageSource,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor, nil)
+
+			if item.evictionsExpected != podEvictor.TotalEvicted() {
+				t.Errorf("Expected %v evictions, got %v", item.evictionsExpected, podEvictor.TotalEvicted())
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWithDeviationThresholds(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 4000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+
+	// n1, n2 and n3 have 60%, 20% and 10% of their CPU requested, 30% on average
+	var pods []*v1.Pod
+	for i := 1; i <= 6; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("pod_%d_%s", i, n1.Name), 400, 0, n1.Name, test.SetRSOwnerRef))
+	}
+	pods = append(pods,
+		test.BuildTestPod(fmt.Sprintf("pod_7_%s", n2.Name), 400, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod(fmt.Sprintf("pod_8_%s", n2.Name), 400, 0, n2.Name, test.SetRSOwnerRef),
+		test.BuildTestPod(fmt.Sprintf("pod_9_%s", n3.Name), 400, 0, n3.Name, test.SetRSOwnerRef),
+	)
+
+	tests := []struct {
+		name                   string
+		useDeviationThresholds bool
+		evictionsExpected      int
+	}{
+		{
+			// n1 and n2 are above 10%, n3 has no room left below it
+			name:              "Absolute thresholds",
+			evictionsExpected: 0,
+		},
+		{
+			// n1 is above 40%, n2 and n3 below 20%, n1 being brought down to 40%
+			name:                   "Deviation thresholds",
+			useDeviationThresholds: true,
+			evictionsExpected:      2,
+		},
+	}
+
+	for _, item := range tests {
+		t.Run(item.name, func(t *testing.T) {
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			podEvictor := evictions.NewPodEvictor(
+				fakeClient,
+				policyv1.SchemeGroupVersion.String(),
+				false,
+				0,
+				0,
+				0,
+				nodes,
+				false,
+				false,
+				false,
+			)
+
+			strategy := api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					NodeResourceUtilizationThresholds: &api.NodeResourceUtilizationThresholds{
+						Thresholds:             api.ResourceThresholds{v1.ResourceCPU: 10},
+						TargetThresholds:       api.ResourceThresholds{v1.ResourceCPU: 10},
+						UseDeviationThresholds: item.useDeviationThresholds,
+					},
+				},
+			}
+			LowNodeUtilization(ctx, fakeClient, strategy, nodes, podEvictor, nil)
 
 			if item.evictionsExpected != podEvictor.TotalEvicted() {
 				t.Errorf("Expected %v evictions, got %v", item.evictionsExpected, podEvictor.TotalEvicted())
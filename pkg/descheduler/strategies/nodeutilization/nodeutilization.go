@@ -21,6 +21,7 @@ import (
 	"fmt"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -59,6 +60,11 @@ func validateNodeUtilizationParams(params *api.StrategyParameters) error {
 	if params.ThresholdPriority != nil && params.ThresholdPriorityClassName != "" {
 		return fmt.Errorf("only one of thresholdPriority and thresholdPriorityClassName can be set")
 	}
+	switch params.NodeResourceUtilizationThresholds.UsageSource {
+	case "", UsageSourceRequests, UsageSourceMetrics:
+	default:
+		return fmt.Errorf("unsupported usage source %q", params.NodeResourceUtilizationThresholds.UsageSource)
+	}
 
 	return nil
 }
@@ -76,25 +82,51 @@ func validateThresholds(thresholds api.ResourceThresholds) error {
 	return nil
 }
 
+// getNodeUsage computes the usage of the nodes and the resource thresholds they are classified with. With
+// useDeviationThresholds, lowThreshold and highThreshold are the deviations below and above the average
+// utilization of the nodes rather than percentages of their capacity.
 func getNodeUsage(
 	ctx context.Context,
 	client clientset.Interface,
 	nodes []*v1.Node,
 	lowThreshold, highThreshold api.ResourceThresholds,
+	useDeviationThresholds bool,
 	resourceNames []v1.ResourceName,
+	podInformer coreinformers.PodInformer,
+	usageClient usageClient,
 ) []NodeUsage {
 	var nodeUsageList []NodeUsage
 
 	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(ctx, client, node)
+		pods, err := podutil.ListPodsOnANode(ctx, client, node, podutil.WithPodInformer(podInformer))
 		if err != nil {
 			klog.V(2).InfoS("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
 			continue
 		}
+		usage, err := usageClient.nodeUtilization(node, pods, resourceNames)
+		if err != nil {
+			klog.V(2).InfoS("Node will not be processed, error reading its usage", "node", klog.KObj(node), "err", err)
+			continue
+		}
 
+		nodeUsageList = append(nodeUsageList, NodeUsage{
+			node:    node,
+			usage:   usage,
+			allPods: pods,
+		})
+	}
+
+	if useDeviationThresholds {
+		average := averageResourceUsagePercentages(nodeUsageList)
+		lowThreshold, highThreshold = deviationThresholds(lowThreshold, highThreshold, average)
+		klog.V(1).InfoS("Thresholds computed from the average utilization", "average", average, "thresholds", lowThreshold, "targetThresholds", highThreshold)
+	}
+
+	for i := range nodeUsageList {
 		// A threshold is in percentages but in <0;100> interval.
 		// Performing `threshold * 0.01` will convert <0;100> interval into <0;1>.
 		// Multiplying it with capacity will give fraction of the capacity corresponding to the given high/low resource threshold in Quantity units.
+		node := nodeUsageList[i].node
 		nodeCapacity := node.Status.Capacity
 		if len(node.Status.Allocatable) > 0 {
 			nodeCapacity = node.Status.Allocatable
@@ -122,13 +154,8 @@ func getNodeUsage(
 			}
 		}
 
-		nodeUsageList = append(nodeUsageList, NodeUsage{
-			node:                  node,
-			usage:                 nodeUtilization(node, pods, resourceNames),
-			allPods:               pods,
-			lowResourceThreshold:  lowResourceThreshold,
-			highResourceThreshold: highResourceThreshold,
-		})
+		nodeUsageList[i].lowResourceThreshold = lowResourceThreshold
+		nodeUsageList[i].highResourceThreshold = highResourceThreshold
 	}
 
 	return nodeUsageList
@@ -151,6 +178,51 @@ func resourceUsagePercentages(nodeUsage NodeUsage) map[v1.ResourceName]float64 {
 	return resourceUsagePercentage
 }
 
+// averageResourceUsagePercentages computes the average utilization of every resource across the nodes
+func averageResourceUsagePercentages(nodeUsages []NodeUsage) map[v1.ResourceName]float64 {
+	total := map[v1.ResourceName]float64{}
+	count := map[v1.ResourceName]int{}
+	for _, nodeUsage := range nodeUsages {
+		for name, percentage := range resourceUsagePercentages(nodeUsage) {
+			total[name] += percentage
+			count[name]++
+		}
+	}
+
+	average := make(map[v1.ResourceName]float64, len(total))
+	for name := range total {
+		average[name] = total[name] / float64(count[name])
+	}
+	return average
+}
+
+// deviationThresholds turns the deviations below and above the average utilization of every resource into
+// thresholds, kept in the [0, 100] range. The resources the deviations leave at 100%, not configured among
+// them, keep being left out of the classification.
+func deviationThresholds(lowDeviation, highDeviation api.ResourceThresholds, average map[v1.ResourceName]float64) (api.ResourceThresholds, api.ResourceThresholds) {
+	lowThreshold := make(api.ResourceThresholds, len(lowDeviation))
+	highThreshold := make(api.ResourceThresholds, len(highDeviation))
+	for name := range lowDeviation {
+		if lowDeviation[name] == MaxResourcePercentage && highDeviation[name] == MaxResourcePercentage {
+			lowThreshold[name], highThreshold[name] = MaxResourcePercentage, MaxResourcePercentage
+			continue
+		}
+		lowThreshold[name] = clampPercentage(api.Percentage(average[name]) - lowDeviation[name])
+		highThreshold[name] = clampPercentage(api.Percentage(average[name]) + highDeviation[name])
+	}
+	return lowThreshold, highThreshold
+}
+
+func clampPercentage(percentage api.Percentage) api.Percentage {
+	if percentage < MinResourcePercentage {
+		return MinResourcePercentage
+	}
+	if percentage > MaxResourcePercentage {
+		return MaxResourcePercentage
+	}
+	return percentage
+}
+
 // classifyNodes classifies the nodes into low-utilization or high-utilization nodes. If a node lies between
 // low and high thresholds, it is simply ignored.
 func classifyNodes(
@@ -174,15 +246,17 @@ func classifyNodes(
 	return lowNodes, highNodes
 }
 
-// evictPodsFromSourceNodes evicts pods based on priority, if all the pods on the node have priority, if not
-// evicts them based on QoS as fallback option.
+// evictPodsFromSourceNodes evicts the pods passing podFilter from the source nodes, in the order sortPods
+// puts them in.
 // TODO: @ravig Break this function into smaller functions.
 func evictPodsFromSourceNodes(
 	ctx context.Context,
 	sourceNodes, destinationNodes []NodeUsage,
 	podEvictor *evictions.PodEvictor,
 	podFilter func(pod *v1.Pod) bool,
+	sortPods func(pods []*v1.Pod),
 	resourceNames []v1.ResourceName,
+	usageClient usageClient,
 	strategy string,
 	continueEviction continueEvictionCond,
 ) {
@@ -233,10 +307,8 @@ func evictPodsFromSourceNodes(
 			continue
 		}
 
-		klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
-		// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
-		podutil.SortPodsBasedOnPriorityLowToHigh(removablePods)
-		evictPods(ctx, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, strategy, continueEviction)
+		sortPods(removablePods)
+		evictPods(ctx, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, usageClient, strategy, continueEviction)
 		klog.V(1).InfoS("Evicted pods from node", "node", klog.KObj(node.node), "evictedPods", podEvictor.NodeEvicted(node.node), "usage", node.usage)
 	}
 }
@@ -248,6 +320,7 @@ func evictPods(
 	totalAvailableUsage map[v1.ResourceName]*resource.Quantity,
 	taintsOfLowNodes map[string][]v1.Taint,
 	podEvictor *evictions.PodEvictor,
+	usageClient usageClient,
 	strategy string,
 	continueEviction continueEvictionCond,
 ) {
@@ -261,6 +334,9 @@ func evictPods(
 
 			success, err := podEvictor.EvictPod(ctx, pod, nodeUsage.node, strategy)
 			if err != nil {
+				if evictions.IsNamespaceLimitReached(err) {
+					continue
+				}
 				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 				break
 			}
@@ -273,7 +349,7 @@ func evictPods(
 						nodeUsage.usage[name].Sub(*resource.NewQuantity(1, resource.DecimalSI))
 						totalAvailableUsage[name].Sub(*resource.NewQuantity(1, resource.DecimalSI))
 					} else {
-						quantity := utils.GetResourceRequestQuantity(pod, name)
+						quantity := usageClient.podUsage(pod, name)
 						nodeUsage.usage[name].Sub(quantity)
 						totalAvailableUsage[name].Sub(quantity)
 					}
@@ -21,6 +21,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"math"
+	"reflect"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"testing"
 )
@@ -156,3 +157,26 @@ func TestResourceUsagePercentages(t *testing.T) {
 
 	t.Logf("resourceUsagePercentage: %#v\n", resourceUsagePercentage)
 }
+
+func TestDeviationThresholds(t *testing.T) {
+	average := map[v1.ResourceName]float64{
+		v1.ResourceCPU:    30,
+		v1.ResourceMemory: 90,
+		v1.ResourcePods:   20,
+	}
+	lowThreshold, highThreshold := deviationThresholds(
+		api.ResourceThresholds{v1.ResourceCPU: 10, v1.ResourceMemory: 5, v1.ResourcePods: MaxResourcePercentage},
+		api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 20, v1.ResourcePods: MaxResourcePercentage},
+		average,
+	)
+
+	expectedLowThreshold := api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 85, v1.ResourcePods: MaxResourcePercentage}
+	// the memory threshold is capped to 100%, the pods left out of the classification
+	expectedHighThreshold := api.ResourceThresholds{v1.ResourceCPU: 50, v1.ResourceMemory: MaxResourcePercentage, v1.ResourcePods: MaxResourcePercentage}
+	if !reflect.DeepEqual(lowThreshold, expectedLowThreshold) {
+		t.Errorf("Expected thresholds %v, got %v", expectedLowThreshold, lowThreshold)
+	}
+	if !reflect.DeepEqual(highThreshold, expectedHighThreshold) {
+		t.Errorf("Expected targetThresholds %v, got %v", expectedHighThreshold, highThreshold)
+	}
+}
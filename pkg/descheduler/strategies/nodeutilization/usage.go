@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// UsageSourceRequests computes the usage of the nodes out of the requests of their pods
+	UsageSourceRequests = "requests"
+	// UsageSourceMetrics reads the CPU and memory usage of the nodes and pods from the metrics.k8s.io API
+	UsageSourceMetrics = "metrics"
+)
+
+// the usage samples read from the metrics.k8s.io API are kept across the descheduling cycles,
+// the strategies being created for every cycle
+var (
+	lowNodeUtilizationHistory  = newUsageHistory()
+	highNodeUtilizationHistory = newUsageHistory()
+)
+
+// usageClient tells the resource usage of the nodes and of their pods
+type usageClient interface {
+	// nodeUtilization returns the usage of the node running pods
+	nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error)
+	// podUsage returns the amount of the resource the pod uses
+	podUsage(pod *v1.Pod, name v1.ResourceName) resource.Quantity
+}
+
+// newUsageClient creates the usage client of the usage source the thresholds configure
+func newUsageClient(ctx context.Context, client clientset.Interface, metricsClient metricsclientset.Interface, podInformer coreinformers.PodInformer, params *api.NodeResourceUtilizationThresholds, history *usageHistory) (usageClient, error) {
+	switch params.UsageSource {
+	case "", UsageSourceRequests:
+		return requestsUsage{}, nil
+	case UsageSourceMetrics:
+		if metricsClient == nil {
+			return nil, fmt.Errorf("no client of the metrics.k8s.io API configured")
+		}
+		var window time.Duration
+		if params.MetricsWindowSeconds != nil {
+			window = time.Duration(*params.MetricsWindowSeconds) * time.Second
+		}
+		pods, err := listPods(ctx, client, podInformer)
+		if err != nil {
+			return nil, err
+		}
+		return newMetricsUsage(ctx, metricsClient, pods, window, history)
+	default:
+		return nil, fmt.Errorf("unsupported usage source %q", params.UsageSource)
+	}
+}
+
+// requestsUsage accounts for the resources requested by the pods as the resources they use
+type requestsUsage struct{}
+
+func (requestsUsage) nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
+	return nodeUtilization(node, pods, resourceNames), nil
+}
+
+func (requestsUsage) podUsage(pod *v1.Pod, name v1.ResourceName) resource.Quantity {
+	return utils.GetResourceRequestQuantity(pod, name)
+}
+
+// metricsUsage accounts for the CPU and memory the nodes and pods use according to the metrics.k8s.io API.
+// The extended resources are not reported by the API, their requests are accounted for instead.
+type metricsUsage struct {
+	nodes map[string]v1.ResourceList
+	pods  map[types.UID]v1.ResourceList
+}
+
+// newMetricsUsage reads the usage of all the nodes and pods at once. With a window, the usage is
+// averaged with the samples read over the window in the previous descheduling cycles, one per cycle.
+// The pod metrics only name the pods, their samples are kept by the UID of the pod of that name
+// among pods, so that a pod recreated under the same name, e.g. by a StatefulSet, does not inherit
+// the samples of the deleted one.
+func newMetricsUsage(ctx context.Context, metricsClient metricsclientset.Interface, pods []*v1.Pod, window time.Duration, history *usageHistory) (*metricsUsage, error) {
+	nodeMetrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list node metrics: %v", err)
+	}
+	podMetrics, err := metricsClient.MetricsV1beta1().PodMetricses(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pod metrics: %v", err)
+	}
+
+	podUIDs := make(map[string]types.UID, len(pods))
+	for _, pod := range pods {
+		podUIDs[pod.Namespace+"/"+pod.Name] = pod.UID
+	}
+
+	now := time.Now()
+	usage := &metricsUsage{
+		nodes: make(map[string]v1.ResourceList, len(nodeMetrics.Items)),
+		pods:  make(map[types.UID]v1.ResourceList, len(podMetrics.Items)),
+	}
+	for _, m := range nodeMetrics.Items {
+		key := "node/" + m.Name
+		usage.nodes[m.Name] = history.average(key, sampleTime(m.Timestamp, now), m.Usage, window)
+	}
+	for _, m := range podMetrics.Items {
+		// the pods not listed, just created or deleted, are not evicted, their metrics are not needed
+		uid, ok := podUIDs[m.Namespace+"/"+m.Name]
+		if !ok {
+			continue
+		}
+		podUsage := v1.ResourceList{
+			v1.ResourceCPU:    *resource.NewMilliQuantity(0, resource.DecimalSI),
+			v1.ResourceMemory: *resource.NewQuantity(0, resource.BinarySI),
+		}
+		for _, c := range m.Containers {
+			for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+				if quantity, ok := c.Usage[name]; ok {
+					total := podUsage[name]
+					total.Add(quantity)
+					podUsage[name] = total
+				}
+			}
+		}
+		usage.pods[uid] = history.average("pod/"+string(uid), sampleTime(m.Timestamp, now), podUsage, window)
+	}
+	history.expire(now, window)
+
+	return usage, nil
+}
+
+// listPods lists all the pods, from the informer cache once synced like the pods of the nodes are
+func listPods(ctx context.Context, client clientset.Interface, podInformer coreinformers.PodInformer) ([]*v1.Pod, error) {
+	if podInformer != nil && podInformer.Informer().HasSynced() {
+		return podInformer.Lister().List(labels.Everything())
+	}
+	podList, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pods: %v", err)
+	}
+	pods := make([]*v1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods, nil
+}
+
+func (u *metricsUsage) nodeUtilization(node *v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
+	nodeUsage, ok := u.nodes[node.Name]
+	if !ok {
+		return nil, fmt.Errorf("no metrics reported for the node")
+	}
+	usage := nodeUtilization(node, pods, resourceNames)
+	cpu, memory := nodeUsage[v1.ResourceCPU], nodeUsage[v1.ResourceMemory]
+	usage[v1.ResourceCPU] = resource.NewMilliQuantity(cpu.MilliValue(), resource.DecimalSI)
+	usage[v1.ResourceMemory] = resource.NewQuantity(memory.Value(), resource.BinarySI)
+	return usage, nil
+}
+
+// podUsage falls back to the requests of the pods not reported yet, e.g. just started
+func (u *metricsUsage) podUsage(pod *v1.Pod, name v1.ResourceName) resource.Quantity {
+	if name == v1.ResourceCPU || name == v1.ResourceMemory {
+		if podUsage, ok := u.pods[pod.UID]; ok {
+			return podUsage[name]
+		}
+	}
+	return utils.GetResourceRequestQuantity(pod, name)
+}
+
+// sampleTime returns the time the metrics were collected at, now when not reported
+func sampleTime(timestamp metav1.Time, now time.Time) time.Time {
+	if timestamp.IsZero() {
+		return now
+	}
+	return timestamp.Time
+}
+
+// usageSample is the usage of a node or pod read at a given time
+type usageSample struct {
+	timestamp time.Time
+	usage     v1.ResourceList
+}
+
+// usageHistory keeps the usage samples of the nodes and pods to smooth their usage over a window
+type usageHistory struct {
+	mu      sync.Mutex
+	samples map[string][]usageSample
+}
+
+func newUsageHistory() *usageHistory {
+	return &usageHistory{samples: map[string][]usageSample{}}
+}
+
+// average records the usage read for key at timestamp and returns the average CPU and memory usage of the
+// samples of key no older than window. The usage read again with the same timestamp is recorded only once.
+// Without a window, the usage is returned as is.
+func (h *usageHistory) average(key string, timestamp time.Time, usage v1.ResourceList, window time.Duration) v1.ResourceList {
+	if window <= 0 {
+		return usage
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[key]
+	if n := len(samples); n == 0 || samples[n-1].timestamp.Before(timestamp) {
+		samples = append(samples, usageSample{timestamp: timestamp, usage: usage})
+	}
+	for len(samples) > 1 && !samples[0].timestamp.After(timestamp.Add(-window)) {
+		samples = samples[1:]
+	}
+	h.samples[key] = samples
+
+	var cpu, memory int64
+	for _, sample := range samples {
+		sampleCPU, sampleMemory := sample.usage[v1.ResourceCPU], sample.usage[v1.ResourceMemory]
+		cpu += sampleCPU.MilliValue()
+		memory += sampleMemory.Value()
+	}
+	n := int64(len(samples))
+	return v1.ResourceList{
+		v1.ResourceCPU:    *resource.NewMilliQuantity(cpu/n, resource.DecimalSI),
+		v1.ResourceMemory: *resource.NewQuantity(memory/n, resource.BinarySI),
+	}
+}
+
+// expire forgets the nodes and pods with no sample read over the window, e.g. deleted
+func (h *usageHistory) expire(now time.Time, window time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key, samples := range h.samples {
+		if samples[len(samples)-1].timestamp.Before(now.Add(-window)) {
+			delete(h.samples, key)
+		}
+	}
+}
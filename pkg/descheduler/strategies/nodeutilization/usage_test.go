@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+// the fake clientset does not map the metrics kinds to the resources its client requests, the metrics
+// are added to its tracker under the requested resources
+var (
+	nodeMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+	podMetricsResource  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+)
+
+func addNodeMetrics(t *testing.T, client *metricsfake.Clientset, name string, millicpu int64) {
+	metrics := &metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Usage: v1.ResourceList{
+			v1.ResourceCPU:    *resource.NewMilliQuantity(millicpu, resource.DecimalSI),
+			v1.ResourceMemory: *resource.NewQuantity(1000, resource.BinarySI),
+		},
+	}
+	if err := client.Tracker().Create(nodeMetricsResource, metrics, ""); err != nil {
+		t.Fatalf("Unable to add node metrics: %v", err)
+	}
+}
+
+func addPodMetrics(t *testing.T, client *metricsfake.Clientset, pod *v1.Pod, millicpu int64) {
+	metrics := &metricsv1beta1.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{
+				Name: "container",
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    *resource.NewMilliQuantity(millicpu, resource.DecimalSI),
+					v1.ResourceMemory: *resource.NewQuantity(100, resource.BinarySI),
+				},
+			},
+		},
+	}
+	if err := client.Tracker().Create(podMetricsResource, metrics, pod.Namespace); err != nil {
+		t.Fatalf("Unable to add pod metrics: %v", err)
+	}
+}
+
+func TestMetricsUsage(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, n1.Name, func(pod *v1.Pod) { pod.UID = "p1" })
+	p2 := test.BuildTestPod("p2", 100, 0, n1.Name, func(pod *v1.Pod) { pod.UID = "p2" })
+
+	client := metricsfake.NewSimpleClientset()
+	addNodeMetrics(t, client, n1.Name, 1500)
+	addPodMetrics(t, client, p1, 700)
+
+	usage, err := newMetricsUsage(ctx, client, []*v1.Pod{p1, p2}, 0, newUsageHistory())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	nodeUsage, err := usage.nodeUtilization(n1, []*v1.Pod{p1, p2}, []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cpu := nodeUsage[v1.ResourceCPU].MilliValue(); cpu != 1500 {
+		t.Errorf("Expected node CPU usage 1500m, got %vm", cpu)
+	}
+	if pods := nodeUsage[v1.ResourcePods].Value(); pods != 2 {
+		t.Errorf("Expected 2 pods, got %v", pods)
+	}
+	if _, err := usage.nodeUtilization(n2, nil, []v1.ResourceName{v1.ResourceCPU}); err == nil {
+		t.Errorf("Expected an error for the node without metrics")
+	}
+
+	cpu := usage.podUsage(p1, v1.ResourceCPU)
+	if cpu.MilliValue() != 700 {
+		t.Errorf("Expected pod CPU usage 700m, got %vm", cpu.MilliValue())
+	}
+	// the pod without metrics is accounted for with its requests
+	cpu = usage.podUsage(p2, v1.ResourceCPU)
+	if cpu.MilliValue() != 100 {
+		t.Errorf("Expected pod CPU usage 100m, got %vm", cpu.MilliValue())
+	}
+}
+
+func TestMetricsUsageOfRecreatedPod(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	deleted := test.BuildTestPod("p1", 100, 0, n1.Name, func(pod *v1.Pod) { pod.UID = "deleted" })
+	recreated := deleted.DeepCopy()
+	recreated.UID = types.UID("recreated")
+	history := newUsageHistory()
+
+	client := metricsfake.NewSimpleClientset()
+	addNodeMetrics(t, client, n1.Name, 1500)
+	addPodMetrics(t, client, deleted, 1000)
+	if _, err := newMetricsUsage(ctx, client, []*v1.Pod{deleted}, 5*time.Minute, history); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the pod recreated under the same name does not inherit the samples of the deleted one
+	client = metricsfake.NewSimpleClientset()
+	addNodeMetrics(t, client, n1.Name, 1500)
+	addPodMetrics(t, client, recreated, 200)
+	usage, err := newMetricsUsage(ctx, client, []*v1.Pod{recreated}, 5*time.Minute, history)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cpu := usage.podUsage(recreated, v1.ResourceCPU); cpu.MilliValue() != 200 {
+		t.Errorf("Expected pod CPU usage 200m, got %vm", cpu.MilliValue())
+	}
+}
+
+func TestUsageHistory(t *testing.T) {
+	start := time.Date(2021, time.October, 1, 0, 0, 0, 0, time.UTC)
+	cpu := func(millicpu int64) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(millicpu, resource.DecimalSI)}
+	}
+
+	tests := []struct {
+		name        string
+		offset      time.Duration
+		millicpu    int64
+		window      time.Duration
+		expectedCPU int64
+	}{
+		{name: "no window", offset: 0, millicpu: 500, window: 0, expectedCPU: 500},
+		{name: "first sample", offset: 0, millicpu: 1000, window: 5 * time.Minute, expectedCPU: 1000},
+		{name: "second sample", offset: time.Minute, millicpu: 2000, window: 5 * time.Minute, expectedCPU: 1500},
+		{name: "sample read again", offset: time.Minute, millicpu: 2000, window: 5 * time.Minute, expectedCPU: 1500},
+		{name: "third sample", offset: 2 * time.Minute, millicpu: 3000, window: 5 * time.Minute, expectedCPU: 2000},
+		{name: "first sample out of the window", offset: 5 * time.Minute, millicpu: 4000, window: 5 * time.Minute, expectedCPU: 3000},
+	}
+
+	history := newUsageHistory()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := history.average("node/n1", start.Add(tc.offset), cpu(tc.millicpu), tc.window)
+			got := usage[v1.ResourceCPU]
+			if got.MilliValue() != tc.expectedCPU {
+				t.Errorf("Expected CPU usage %vm, got %vm", tc.expectedCPU, got.MilliValue())
+			}
+		})
+	}
+
+	history.expire(start.Add(11*time.Minute), 5*time.Minute)
+	if len(history.samples) != 0 {
+		t.Errorf("Expected the samples to expire, got %v", history.samples)
+	}
+}
@@ -22,11 +22,12 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
-	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	"sigs.k8s.io/descheduler/pkg/utils"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -47,68 +48,50 @@ func validateRemovePodsViolatingInterPodAntiAffinityParams(params *api.StrategyP
 	return nil
 }
 
-// RemovePodsViolatingInterPodAntiAffinity evicts pods on the node which are having a pod affinity rules.
-func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsViolatingInterPodAntiAffinityParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingInterPodAntiAffinity parameters")
-		return
-	}
+type podsViolatingInterPodAntiAffinity struct{}
 
-	var includedNamespaces, excludedNamespaces []string
-	var labelSelector *metav1.LabelSelector
-	if strategy.Params != nil {
-		if strategy.Params.Namespaces != nil {
-			includedNamespaces = strategy.Params.Namespaces.Include
-			excludedNamespaces = strategy.Params.Namespaces.Exclude
-		}
-		labelSelector = strategy.Params.LabelSelector
+func newPodsViolatingInterPodAntiAffinity(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateRemovePodsViolatingInterPodAntiAffinityParams(params); err != nil {
+		return nil, err
 	}
+	return &podsViolatingInterPodAntiAffinity{}, nil
+}
+
+// RemovePodsViolatingInterPodAntiAffinity evicts pods on the node which are having a pod affinity rules.
+func RemovePodsViolatingInterPodAntiAffinity(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy(
+		"RemovePodsViolatingInterPodAntiAffinity",
+		newPodsViolatingInterPodAntiAffinity,
+		framework.WithDefaultSortBy(framework.SortByPriority),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
+func (d *podsViolatingInterPodAntiAffinity) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	// all the pods are listed, the ones not evictable may still have anti-affinity with the evictable ones
+	pods, err := h.ListPods(ctx, node, nil)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
+		klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
 		return
 	}
-
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
-	}
-
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
-
-	for _, node := range nodes {
-		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListPodsOnANode(
-			ctx,
-			client,
-			node,
-			podutil.WithNamespaces(includedNamespaces),
-			podutil.WithoutNamespaces(excludedNamespaces),
-			podutil.WithLabelSelector(labelSelector),
-		)
-		if err != nil {
-			return
-		}
-		// sort the evictable Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
-		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
-		totalPods := len(pods)
-		for i := 0; i < totalPods; i++ {
-			if checkPodsWithAntiAffinityExist(pods[i], pods) && evictable.IsEvictable(pods[i]) {
-				success, err := podEvictor.EvictPod(ctx, pods[i], node, "InterPodAntiAffinity")
-				if err != nil {
-					klog.ErrorS(err, "Error evicting pod")
-					break
+	totalPods := len(pods)
+	for i := 0; i < totalPods; i++ {
+		if checkPodsWithAntiAffinityExist(pods[i], pods) && h.PreFilter(pods[i]) {
+			success, err := h.PodEvictor.EvictPod(ctx, pods[i], node, "InterPodAntiAffinity")
+			if err != nil {
+				if evictions.IsNamespaceLimitReached(err) {
+					continue
 				}
+				klog.ErrorS(err, "Error evicting pod")
+				break
+			}
 
-				if success {
-					// Since the current pod is evicted all other pods which have anti-affinity with this
-					// pod need not be evicted.
-					// Update pods.
-					pods = append(pods[:i], pods[i+1:]...)
-					i--
-					totalPods--
-				}
+			if success {
+				// Since the current pod is evicted all other pods which have anti-affinity with this
+				// pod need not be evicted.
+				// Update pods.
+				pods = append(pods[:i], pods[i+1:]...)
+				i--
+				totalPods--
 			}
 		}
 	}
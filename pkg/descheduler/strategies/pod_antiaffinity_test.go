@@ -167,6 +167,8 @@ func TestPodAntiAffinity(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			test.maxPodsToEvictPerNode,
+			0,
+			0,
 			test.nodes,
 			false,
 			false,
@@ -178,7 +180,7 @@ func TestPodAntiAffinity(t *testing.T) {
 			},
 		}
 
-		RemovePodsViolatingInterPodAntiAffinity(ctx, fakeClient, strategy, test.nodes, podEvictor)
+		RemovePodsViolatingInterPodAntiAffinity(ctx, fakeClient, strategy, test.nodes, podEvictor, nil)
 		podsEvicted := podEvictor.TotalEvicted()
 		if podsEvicted != test.expectedEvictedPodCount {
 			t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, test.expectedEvictedPodCount)
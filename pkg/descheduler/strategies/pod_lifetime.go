@@ -22,13 +22,13 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
-	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
-	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 )
 
 func validatePodLifeTimeParams(params *api.StrategyParameters) error {
@@ -55,87 +55,62 @@ func validatePodLifeTimeParams(params *api.StrategyParameters) error {
 	return nil
 }
 
-// PodLifeTime evicts pods on nodes that were created more than strategy.Params.MaxPodLifeTimeSeconds seconds ago.
-func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validatePodLifeTimeParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid PodLifeTime parameters")
-		return
+type podLifeTime struct {
+	params *api.PodLifeTime
+}
+
+func newPodLifeTime(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validatePodLifeTimeParams(params); err != nil {
+		return nil, err
 	}
+	return &podLifeTime{params: params.PodLifeTime}, nil
+}
 
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
+// PodLifeTime evicts pods on nodes that were created more than strategy.Params.MaxPodLifeTimeSeconds seconds ago.
+func PodLifeTime(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	// old pods are evicted whether they fit another node or not
+	framework.NewStrategy("PodLifeTime", newPodLifeTime, framework.WithoutNodeFit())(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
+
+func (d *podLifeTime) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	pods, err := h.ListPods(ctx, node, func(pod *v1.Pod) bool {
+		return d.hasPhase(pod) && d.isOld(pod) && h.PreFilter(pod)
+	})
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
+		klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
 		return
 	}
 
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
-	}
-
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority))
-
-	filter := evictable.IsEvictable
-	if strategy.Params.PodLifeTime.PodStatusPhases != nil {
-		filter = func(pod *v1.Pod) bool {
-			for _, phase := range strategy.Params.PodLifeTime.PodStatusPhases {
-				if string(pod.Status.Phase) == phase {
-					return evictable.IsEvictable(pod)
-				}
-			}
-			return false
+	for _, pod := range pods {
+		success, err := h.PodEvictor.EvictPod(ctx, pod, node, "PodLifeTime")
+		if success {
+			klog.V(1).InfoS("Evicted pod because it exceeded its lifetime", "pod", klog.KObj(pod), "maxPodLifeTime", *d.params.MaxPodLifeTimeSeconds)
 		}
-	}
-
-	for _, node := range nodes {
-		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-
-		pods := listOldPodsOnNode(ctx, client, node, includedNamespaces, excludedNamespaces, strategy.Params.LabelSelector, *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds, filter)
-		for _, pod := range pods {
-			success, err := podEvictor.EvictPod(ctx, pod, node, "PodLifeTime")
-			if success {
-				klog.V(1).InfoS("Evicted pod because it exceeded its lifetime", "pod", klog.KObj(pod), "maxPodLifeTime", *strategy.Params.PodLifeTime.MaxPodLifeTimeSeconds)
-			}
 
-			if err != nil {
-				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
-				break
+		if err != nil {
+			if evictions.IsNamespaceLimitReached(err) {
+				continue
 			}
+			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+			break
 		}
-
 	}
 }
 
-func listOldPodsOnNode(
-	ctx context.Context,
-	client clientset.Interface,
-	node *v1.Node,
-	includedNamespaces, excludedNamespaces []string,
-	labelSelector *metav1.LabelSelector,
-	maxPodLifeTimeSeconds uint,
-	filter func(pod *v1.Pod) bool,
-) []*v1.Pod {
-	pods, err := podutil.ListPodsOnANode(
-		ctx,
-		client,
-		node,
-		podutil.WithFilter(filter),
-		podutil.WithNamespaces(includedNamespaces),
-		podutil.WithoutNamespaces(excludedNamespaces),
-		podutil.WithLabelSelector(labelSelector),
-	)
-	if err != nil {
-		return nil
+// hasPhase tells whether the pod is in one of the phases of the strategy, any phase when none is set
+func (d *podLifeTime) hasPhase(pod *v1.Pod) bool {
+	if d.params.PodStatusPhases == nil {
+		return true
 	}
-
-	var oldPods []*v1.Pod
-	for _, pod := range pods {
-		podAgeSeconds := uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
-		if podAgeSeconds > maxPodLifeTimeSeconds {
-			oldPods = append(oldPods, pod)
+	for _, phase := range d.params.PodStatusPhases {
+		if string(pod.Status.Phase) == phase {
+			return true
 		}
 	}
+	return false
+}
 
-	return oldPods
+func (d *podLifeTime) isOld(pod *v1.Pod) bool {
+	podAgeSeconds := uint(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+	return podAgeSeconds > *d.params.MaxPodLifeTimeSeconds
 }
@@ -125,11 +125,27 @@ func TestPodLifeTime(t *testing.T) {
 	p12.ObjectMeta.OwnerReferences = ownerRef1
 	p13.ObjectMeta.OwnerReferences = ownerRef1
 
+	// Setup three old pods, two in the `dev` Namespace and one in the `prod` Namespace
+	p14 := test.BuildTestPod("p14", 100, 0, node1.Name, nil)
+	p14.Namespace = "dev"
+	p14.ObjectMeta.CreationTimestamp = olderPodCreationTime
+	p15 := test.BuildTestPod("p15", 100, 0, node1.Name, nil)
+	p15.Namespace = "dev"
+	p15.ObjectMeta.CreationTimestamp = olderPodCreationTime
+	p16 := test.BuildTestPod("p16", 100, 0, node1.Name, nil)
+	p16.Namespace = "prod"
+	p16.ObjectMeta.CreationTimestamp = metav1.NewTime(olderPodCreationTime.Add(time.Hour))
+
+	p14.ObjectMeta.OwnerReferences = ownerRef1
+	p15.ObjectMeta.OwnerReferences = ownerRef1
+	p16.ObjectMeta.OwnerReferences = ownerRef1
+
 	var maxLifeTime uint = 600
 	testCases := []struct {
 		description             string
 		strategy                api.DeschedulerStrategy
 		maxPodsToEvictPerNode   int
+		maxPodsToEvictPerNs     int
 		pods                    []v1.Pod
 		nodes                   []*v1.Node
 		expectedEvictedPodCount int
@@ -246,6 +262,20 @@ func TestPodLifeTime(t *testing.T) {
 			nodes:                   []*v1.Node{node1},
 			expectedEvictedPodCount: 1,
 		},
+		{
+			description: "Three old pods in two Namespaces, 1 evicted per Namespace",
+			strategy: api.DeschedulerStrategy{
+				Enabled: true,
+				Params: &api.StrategyParameters{
+					PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxLifeTime},
+				},
+			},
+			maxPodsToEvictPerNode:   5,
+			maxPodsToEvictPerNs:     1,
+			pods:                    []v1.Pod{*p14, *p15, *p16},
+			nodes:                   []*v1.Node{node1},
+			expectedEvictedPodCount: 2,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -260,13 +290,15 @@ func TestPodLifeTime(t *testing.T) {
 			policyv1.SchemeGroupVersion.String(),
 			false,
 			tc.maxPodsToEvictPerNode,
+			tc.maxPodsToEvictPerNs,
+			0,
 			tc.nodes,
 			false,
 			false,
 			tc.ignorePvcPods,
 		)
 
-		PodLifeTime(ctx, fakeClient, tc.strategy, tc.nodes, podEvictor)
+		PodLifeTime(ctx, fakeClient, tc.strategy, tc.nodes, podEvictor, nil)
 		podsEvicted := podEvictor.TotalEvicted()
 		if podsEvicted != tc.expectedEvictedPodCount {
 			t.Errorf("Test error for description: %s. Expected evicted pods count %v, got %v", tc.description, tc.expectedEvictedPodCount, podsEvicted)
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/defragmentation"
+	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/nodeutilization"
+)
+
+const (
+	// minResourcePercentage is the minimum value of a resource threshold
+	minResourcePercentage = 0
+	// maxResourcePercentage is the maximum value of a resource threshold
+	maxResourcePercentage = 100
+)
+
+var (
+	// frameworkFilters are the filters of the strategies run by framework.NewStrategy
+	frameworkFilters = &Filters{Namespaces: true, LabelSelector: true, ThresholdPriority: true, NodeFit: true}
+	// podLifeTimeFilters are the filters of PodLifeTime, which evicts the old pods whether they fit another node or not
+	podLifeTimeFilters = &Filters{Namespaces: true, LabelSelector: true, ThresholdPriority: true}
+)
+
+func init() {
+	for _, strategy := range []Strategy{
+		{Name: "RemoveDuplicates", New: newFactory(strategies.RemoveDuplicatePods), SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemoveDuplicatesArgs{} }},
+		{Name: "LowNodeUtilization", New: newFactory(nodeutilization.LowNodeUtilization), ValidateParams: validateLowNodeUtilizationParams, SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.LowNodeUtilizationArgs{} }},
+		{Name: "HighNodeUtilization", New: newFactory(nodeutilization.HighNodeUtilization), ValidateParams: validateHighNodeUtilizationParams, SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.HighNodeUtilizationArgs{} }},
+		{Name: "RemovePodsViolatingInterPodAntiAffinity", New: newFactory(strategies.RemovePodsViolatingInterPodAntiAffinity), SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemovePodsViolatingInterPodAntiAffinityArgs{} }},
+		{Name: "RemovePodsViolatingNodeAffinity", New: newFactory(strategies.RemovePodsViolatingNodeAffinity), ValidateParams: validateNodeAffinityParams, DefaultParams: defaultNodeAffinityParams, SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemovePodsViolatingNodeAffinityArgs{} }},
+		{Name: "RemovePodsViolatingNodeTaints", New: newFactory(strategies.RemovePodsViolatingNodeTaints), SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemovePodsViolatingNodeTaintsArgs{} }},
+		{Name: "RemovePodsHavingTooManyRestarts", New: newFactory(strategies.RemovePodsHavingTooManyRestarts), ValidateParams: validateTooManyRestartsParams, SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemovePodsHavingTooManyRestartsArgs{} }},
+		{Name: "PodLifeTime", New: newFactory(strategies.PodLifeTime), ValidateParams: validatePodLifeTimeParams, SupportsSortBy: true, Filters: podLifeTimeFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.PodLifeTimeArgs{} }},
+		{Name: "RemovePodsViolatingTopologySpreadConstraint", New: newFactory(strategies.RemovePodsViolatingTopologySpreadConstraint), SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemovePodsViolatingTopologySpreadConstraintArgs{} }},
+		{Name: "RemoveFailedPods", New: newFactory(strategies.RemoveFailedPods), SupportsSortBy: true, Filters: frameworkFilters, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.RemoveFailedPodsArgs{} }},
+		{Name: "BalancePodsOnNodeForDefragmentation", New: newFactory(defragmentation.BalancePodsOnNodeForDefragmentation), ValidateParams: validateDefragmentationParams, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.BalancePodsOnNodeForDefragmentationArgs{} }},
+		{Name: "PlacePodsOnNodeForDefragmentation", New: newFactory(defragmentation.PlacePodsOnNodeForDefragmentation), ValidateParams: validateDefragmentationParams, SupportsSortBy: true, NewArgs: func() v1alpha2.StrategyArgs { return &v1alpha2.PlacePodsOnNodeForDefragmentationArgs{} }},
+	} {
+		Register(strategy)
+	}
+}
+
+// newFactory creates the factory of a strategy implemented by a plain function
+func newFactory(f StrategyFunction) Factory {
+	return func() StrategyFunction { return f }
+}
+
+// defaultNodeAffinityParams checks the only node affinity type supported when none is configured
+func defaultNodeAffinityParams() *api.StrategyParameters {
+	return &api.StrategyParameters{NodeAffinityType: []string{"requiredDuringSchedulingIgnoredDuringExecution"}}
+}
+
+func validateLowNodeUtilizationParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	fldPath = fldPath.Child("nodeResourceUtilizationThresholds")
+	if params == nil || params.NodeResourceUtilizationThresholds == nil {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	thresholds, targetThresholds := params.NodeResourceUtilizationThresholds.Thresholds, params.NodeResourceUtilizationThresholds.TargetThresholds
+
+	allErrs := validateThresholds(thresholds, fldPath.Child("thresholds"))
+	allErrs = append(allErrs, validateThresholds(targetThresholds, fldPath.Child("targetThresholds"))...)
+	for _, name := range sortedResourceNames(thresholds) {
+		if targetValue, ok := targetThresholds[name]; !ok {
+			if len(targetThresholds) > 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("targetThresholds").Key(string(name)), "thresholds and targetThresholds must configure the same resources"))
+			}
+		} else if thresholds[name] > targetValue && !params.NodeResourceUtilizationThresholds.UseDeviationThresholds {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("thresholds").Key(string(name)), thresholds[name], "must not be greater than the targetThresholds one"))
+		}
+	}
+	for _, name := range sortedResourceNames(targetThresholds) {
+		if _, ok := thresholds[name]; !ok && len(thresholds) > 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("thresholds").Key(string(name)), "thresholds and targetThresholds must configure the same resources"))
+		}
+	}
+	allErrs = append(allErrs, validateUsageSource(params.NodeResourceUtilizationThresholds, fldPath)...)
+	return append(allErrs, validateNumberOfNodes(params.NodeResourceUtilizationThresholds.NumberOfNodes, fldPath.Child("numberOfNodes"))...)
+}
+
+func validateHighNodeUtilizationParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	fldPath = fldPath.Child("nodeResourceUtilizationThresholds")
+	if params == nil || params.NodeResourceUtilizationThresholds == nil {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+
+	allErrs := validateThresholds(params.NodeResourceUtilizationThresholds.Thresholds, fldPath.Child("thresholds"))
+	if params.NodeResourceUtilizationThresholds.TargetThresholds != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("targetThresholds"), "not applicable to HighNodeUtilization"))
+	}
+	if params.NodeResourceUtilizationThresholds.UseDeviationThresholds {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("useDeviationThresholds"), "not applicable to HighNodeUtilization"))
+	}
+	allErrs = append(allErrs, validateUsageSource(params.NodeResourceUtilizationThresholds, fldPath)...)
+	return append(allErrs, validateNumberOfNodes(params.NodeResourceUtilizationThresholds.NumberOfNodes, fldPath.Child("numberOfNodes"))...)
+}
+
+// validateThresholds checks that some resources are configured, with a percentage in the [0, 100] range
+func validateThresholds(thresholds api.ResourceThresholds, fldPath *field.Path) field.ErrorList {
+	if len(thresholds) == 0 {
+		return field.ErrorList{field.Required(fldPath, "no resource threshold is configured")}
+	}
+	var allErrs field.ErrorList
+	for _, name := range sortedResourceNames(thresholds) {
+		if percentage := thresholds[name]; percentage < minResourcePercentage || percentage > maxResourcePercentage {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(string(name)), percentage, "must be in the [0, 100] range"))
+		}
+	}
+	return allErrs
+}
+
+// validateUsageSource checks the usage source is supported, the metrics window applying to the metrics one only
+func validateUsageSource(thresholds *api.NodeResourceUtilizationThresholds, fldPath *field.Path) field.ErrorList {
+	switch thresholds.UsageSource {
+	case "", nodeutilization.UsageSourceRequests:
+		if thresholds.MetricsWindowSeconds != nil {
+			return field.ErrorList{field.Forbidden(fldPath.Child("metricsWindowSeconds"), "only applicable to the metrics usage source")}
+		}
+	case nodeutilization.UsageSourceMetrics:
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("usageSource"), thresholds.UsageSource, []string{nodeutilization.UsageSourceRequests, nodeutilization.UsageSourceMetrics})}
+	}
+	return nil
+}
+
+func validateNumberOfNodes(numberOfNodes int, fldPath *field.Path) field.ErrorList {
+	if numberOfNodes < 0 {
+		return field.ErrorList{field.Invalid(fldPath, numberOfNodes, "must not be negative")}
+	}
+	return nil
+}
+
+func validateNodeAffinityParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	fldPath = fldPath.Child("nodeAffinityType")
+	if params == nil || len(params.NodeAffinityType) == 0 {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	var allErrs field.ErrorList
+	for i, nodeAffinityType := range params.NodeAffinityType {
+		if nodeAffinityType != "requiredDuringSchedulingIgnoredDuringExecution" {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i), nodeAffinityType, []string{"requiredDuringSchedulingIgnoredDuringExecution"}))
+		}
+	}
+	return allErrs
+}
+
+func validateTooManyRestartsParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	fldPath = fldPath.Child("podsHavingTooManyRestarts")
+	if params == nil || params.PodsHavingTooManyRestarts == nil {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	if params.PodsHavingTooManyRestarts.PodRestartThreshold < 1 {
+		return field.ErrorList{field.Invalid(fldPath.Child("podRestartThreshold"), params.PodsHavingTooManyRestarts.PodRestartThreshold, "must be positive")}
+	}
+	return nil
+}
+
+func validatePodLifeTimeParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	fldPath = fldPath.Child("podLifeTime")
+	if params == nil || params.PodLifeTime == nil {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	var allErrs field.ErrorList
+	if params.PodLifeTime.MaxPodLifeTimeSeconds == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("maxPodLifeTimeSeconds"), ""))
+	}
+	for i, phase := range params.PodLifeTime.PodStatusPhases {
+		if phase != string(v1.PodPending) && phase != string(v1.PodRunning) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("podStatusPhases").Index(i), phase, []string{string(v1.PodPending), string(v1.PodRunning)}))
+		}
+	}
+	return allErrs
+}
+
+func validateDefragmentationParams(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList {
+	if params != nil && params.Iterations != nil && *params.Iterations < 0 {
+		return field.ErrorList{field.Invalid(fldPath.Child("iterations"), *params.Iterations, "must not be negative")}
+	}
+	return nil
+}
+
+func sortedResourceNames(thresholds api.ResourceThresholds) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(thresholds))
+	for name := range thresholds {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry holds the strategies the descheduler can run, by name. The in-tree strategies are
+// registered by the package itself, out-of-tree strategies register themselves from the init function of
+// their package, which a custom build of the descheduler imports.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+// StrategyFunction runs a strategy over the nodes, evicting pods through podEvictor
+type StrategyFunction func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer)
+
+// Factory creates the function running a strategy
+type Factory func() StrategyFunction
+
+// ParamsValidator validates the parameters specific to a strategy, params being nil when not set.
+// The parameters shared by the strategies are validated with the policy.
+type ParamsValidator func(params *api.StrategyParameters, fldPath *field.Path) field.ErrorList
+
+// DefaultParams gives the default values of the parameters of a strategy, set on the parameters the policy leaves unset
+type DefaultParams func() *api.StrategyParameters
+
+// Filters tells which of the parameters shared by the strategies a strategy selects the pods it evicts with,
+// before checking the Evictable constraints
+type Filters struct {
+	Namespaces        bool
+	LabelSelector     bool
+	ThresholdPriority bool
+	NodeFit           bool
+}
+
+// Strategy is a strategy the descheduler can run
+type Strategy struct {
+	Name api.StrategyName
+	New  Factory
+	// ValidateParams is optional, the strategy taking no specific parameters when not set
+	ValidateParams ParamsValidator
+	// DefaultParams is optional, the strategy having no default parameters when not set
+	DefaultParams DefaultParams
+	// NewArgs creates the args the strategy is configured with in v1alpha2 policies. It is optional,
+	// the strategy taking no args, only the settings shared by the strategies, when not set.
+	NewArgs v1alpha2.StrategyArgsFactory
+	// SupportsSortBy tells whether the strategy orders the pods it evicts with the sorters named by the
+	// sortBy parameter, e.g. being run by framework.NewStrategy. The parameter is rejected otherwise.
+	SupportsSortBy bool
+	// Filters are used by the explain command. They are optional, the strategy selecting the pods with
+	// criteria of its own, which are not explained, when not set.
+	Filters *Filters
+}
+
+var (
+	mu         sync.RWMutex
+	registered = map[api.StrategyName]Strategy{}
+)
+
+func init() {
+	// the args of v1alpha2 policies are decoded into the args types of the registered strategies
+	v1alpha2.LookupStrategyArgs = lookupArgs
+}
+
+// Register adds a strategy to the registry. It panics when the strategy has no name or factory,
+// or when a strategy with the same name is registered already.
+func Register(strategy Strategy) {
+	if strategy.Name == "" || strategy.New == nil {
+		panic(fmt.Sprintf("strategy %q registered without a name or factory", strategy.Name))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registered[strategy.Name]; ok {
+		panic(fmt.Sprintf("strategy %q registered twice", strategy.Name))
+	}
+	registered[strategy.Name] = strategy
+}
+
+// Lookup gives the strategy registered under name
+func Lookup(name api.StrategyName) (Strategy, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	strategy, ok := registered[name]
+	return strategy, ok
+}
+
+// lookupArgs gives the factory of the args of the strategy registered under name
+func lookupArgs(name v1alpha2.StrategyName) (v1alpha2.StrategyArgsFactory, bool) {
+	strategy, ok := Lookup(api.StrategyName(name))
+	return strategy.NewArgs, ok
+}
+
+// Names gives the names of the registered strategies, in alphabetical order
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDefaultParams sets the parameters of the enabled strategies of the policy left unset, e.g. by a policy
+// setting only the grace period of the strategy, to their default values
+func SetDefaultParams(policy *api.DeschedulerPolicy) {
+	for name, strategy := range policy.Strategies {
+		if !strategy.Enabled {
+			continue
+		}
+		known, ok := Lookup(name)
+		if !ok || known.DefaultParams == nil {
+			continue
+		}
+		params := known.DefaultParams()
+		if strategy.Params != nil {
+			merged := *strategy.Params
+			mergeParams(&merged, params)
+			params = &merged
+		}
+		strategy.Params = params
+		policy.Strategies[name] = strategy
+	}
+}
+
+// mergeParams sets the fields of params holding their zero value to the ones of defaults
+func mergeParams(params, defaults *api.StrategyParameters) {
+	merged, defaulted := reflect.ValueOf(params).Elem(), reflect.ValueOf(defaults).Elem()
+	for i := 0; i < merged.NumField(); i++ {
+		if merged.Field(i).IsZero() {
+			merged.Field(i).Set(defaulted.Field(i))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+)
+
+func TestRegister(t *testing.T) {
+	noop := func(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	}
+	Register(Strategy{Name: "TestRegister", New: newFactory(noop)})
+	if _, ok := Lookup("TestRegister"); !ok {
+		t.Errorf("Expected the registered strategy to be found")
+	}
+
+	for _, strategy := range []Strategy{
+		{Name: "TestRegister", New: newFactory(noop)},
+		{Name: "RemoveDuplicates", New: newFactory(noop)},
+		{Name: "", New: newFactory(noop)},
+		{Name: "TestRegisterWithoutFactory"},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected registering %q to panic", strategy.Name)
+				}
+			}()
+			Register(strategy)
+		}()
+	}
+}
+
+func TestBuiltinStrategies(t *testing.T) {
+	names := Names()
+	for _, name := range []string{"RemoveDuplicates", "LowNodeUtilization", "PodLifeTime", "BalancePodsOnNodeForDefragmentation", "PlacePodsOnNodeForDefragmentation"} {
+		found := false
+		for _, registered := range names {
+			found = found || registered == name
+		}
+		if !found {
+			t.Errorf("Expected %q to be registered, got %v", name, names)
+		}
+		if strategy, _ := Lookup(api.StrategyName(name)); strategy.NewArgs == nil {
+			t.Errorf("Expected %q to take v1alpha2 args", name)
+		}
+	}
+}
+
+func TestSetDefaultParams(t *testing.T) {
+	maxPodLifeTimeSeconds := uint(60)
+	gracePeriodSeconds := int64(30)
+	policy := &api.DeschedulerPolicy{
+		Strategies: api.StrategyList{
+			"RemovePodsViolatingNodeAffinity": {Enabled: true, Params: &api.StrategyParameters{EvictionGracePeriodSeconds: &gracePeriodSeconds}},
+			"PodLifeTime":                     {Enabled: true, Params: &api.StrategyParameters{PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds}}},
+			"RemoveDuplicates":                {Enabled: true},
+		},
+	}
+	SetDefaultParams(policy)
+
+	expected := api.StrategyList{
+		"RemovePodsViolatingNodeAffinity": {Enabled: true, Params: &api.StrategyParameters{
+			NodeAffinityType:           []string{"requiredDuringSchedulingIgnoredDuringExecution"},
+			EvictionGracePeriodSeconds: &gracePeriodSeconds,
+		}},
+		"PodLifeTime":      {Enabled: true, Params: &api.StrategyParameters{PodLifeTime: &api.PodLifeTime{MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds}}},
+		"RemoveDuplicates": {Enabled: true},
+	}
+	if !reflect.DeepEqual(policy.Strategies, expected) {
+		t.Errorf("Expected strategies %+v, got %+v", expected, policy.Strategies)
+	}
+}
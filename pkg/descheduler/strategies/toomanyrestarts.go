@@ -21,13 +21,13 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
-	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
-	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 )
 
 func validateRemovePodsHavingTooManyRestartsParams(params *api.StrategyParameters) error {
@@ -46,63 +46,46 @@ func validateRemovePodsHavingTooManyRestartsParams(params *api.StrategyParameter
 	return nil
 }
 
+type podsHavingTooManyRestarts struct {
+	params *api.PodsHavingTooManyRestarts
+}
+
+func newPodsHavingTooManyRestarts(params *api.StrategyParameters) (framework.Plugin, error) {
+	if err := validateRemovePodsHavingTooManyRestartsParams(params); err != nil {
+		return nil, err
+	}
+	return &podsHavingTooManyRestarts{params: params.PodsHavingTooManyRestarts}, nil
+}
+
 // RemovePodsHavingTooManyRestarts removes the pods that have too many restarts on node.
 // There are too many cases leading this issue: Volume mount failed, app error due to nodes' different settings.
 // As of now, this strategy won't evict daemonsets, mirror pods, critical pods and pods with local storages.
-func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
-	if err := validateRemovePodsHavingTooManyRestartsParams(strategy.Params); err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsHavingTooManyRestarts parameters")
-		return
-	}
+func RemovePodsHavingTooManyRestarts(ctx context.Context, client clientset.Interface, strategy api.DeschedulerStrategy, nodes []*v1.Node, podEvictor *evictions.PodEvictor, podInformer coreinformers.PodInformer) {
+	framework.NewStrategy("RemovePodsHavingTooManyRestarts", newPodsHavingTooManyRestarts)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
-	thresholdPriority, err := utils.GetPriorityFromStrategyParams(ctx, client, strategy.Params)
+func (d *podsHavingTooManyRestarts) Deschedule(ctx context.Context, h *framework.Handle, node *v1.Node) {
+	pods, err := h.ListPods(ctx, node, h.PreFilter)
 	if err != nil {
-		klog.ErrorS(err, "Failed to get threshold priority from strategy's params")
+		klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
 		return
 	}
 
-	var includedNamespaces, excludedNamespaces []string
-	if strategy.Params.Namespaces != nil {
-		includedNamespaces = strategy.Params.Namespaces.Include
-		excludedNamespaces = strategy.Params.Namespaces.Exclude
-	}
-
-	nodeFit := false
-	if strategy.Params != nil {
-		nodeFit = strategy.Params.NodeFit
-	}
-
-	evictable := podEvictor.Evictable(evictions.WithPriorityThreshold(thresholdPriority), evictions.WithNodeFit(nodeFit))
-
-	for _, node := range nodes {
-		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
-		pods, err := podutil.ListPodsOnANode(
-			ctx,
-			client,
-			node,
-			podutil.WithFilter(evictable.IsEvictable),
-			podutil.WithNamespaces(includedNamespaces),
-			podutil.WithoutNamespaces(excludedNamespaces),
-			podutil.WithLabelSelector(strategy.Params.LabelSelector),
-		)
-		if err != nil {
-			klog.ErrorS(err, "Error listing a nodes pods", "node", klog.KObj(node))
+	for i, pod := range pods {
+		restarts, initRestarts := calcContainerRestarts(pod)
+		if d.params.IncludingInitContainers {
+			if restarts+initRestarts < d.params.PodRestartThreshold {
+				continue
+			}
+		} else if restarts < d.params.PodRestartThreshold {
 			continue
 		}
-
-		for i, pod := range pods {
-			restarts, initRestarts := calcContainerRestarts(pod)
-			if strategy.Params.PodsHavingTooManyRestarts.IncludingInitContainers {
-				if restarts+initRestarts < strategy.Params.PodsHavingTooManyRestarts.PodRestartThreshold {
-					continue
-				}
-			} else if restarts < strategy.Params.PodsHavingTooManyRestarts.PodRestartThreshold {
+		if _, err := h.PodEvictor.EvictPod(ctx, pods[i], node, "TooManyRestarts"); err != nil {
+			if evictions.IsNamespaceLimitReached(err) {
 				continue
 			}
-			if _, err := podEvictor.EvictPod(ctx, pods[i], node, "TooManyRestarts"); err != nil {
-				klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
-				break
-			}
+			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
+			break
 		}
 	}
 }
@@ -27,13 +27,15 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/framework"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
-	"sigs.k8s.io/descheduler/pkg/descheduler/strategies/validation"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
@@ -48,25 +50,40 @@ type topology struct {
 	pods []*v1.Pod
 }
 
+// podsViolatingTopologySpreadConstraint holds the parameters of RemovePodsViolatingTopologySpreadConstraint
+type podsViolatingTopologySpreadConstraint struct {
+	includedNamespaces, excludedNamespaces sets.String
+	includeSoftConstraints                 bool
+}
+
+func newPodsViolatingTopologySpreadConstraint(params *api.StrategyParameters) (framework.Plugin, error) {
+	d := &podsViolatingTopologySpreadConstraint{}
+	if params != nil {
+		if params.Namespaces != nil {
+			d.includedNamespaces = sets.NewString(params.Namespaces.Include...)
+			d.excludedNamespaces = sets.NewString(params.Namespaces.Exclude...)
+		}
+		d.includeSoftConstraints = params.IncludeSoftConstraints
+	}
+	return d, nil
+}
+
 func RemovePodsViolatingTopologySpreadConstraint(
 	ctx context.Context,
 	client clientset.Interface,
 	strategy api.DeschedulerStrategy,
 	nodes []*v1.Node,
 	podEvictor *evictions.PodEvictor,
+	podInformer coreinformers.PodInformer,
 ) {
-	strategyParams, err := validation.ValidateAndParseStrategyParams(ctx, client, strategy.Params)
-	if err != nil {
-		klog.ErrorS(err, "Invalid RemovePodsViolatingTopologySpreadConstraint parameters")
-		return
-	}
-
-	evictable := podEvictor.Evictable(
-		evictions.WithPriorityThreshold(strategyParams.ThresholdPriority),
-		evictions.WithNodeFit(strategyParams.NodeFit),
-		evictions.WithLabelSelector(strategyParams.LabelSelector),
-	)
+	framework.NewStrategy(
+		"RemovePodsViolatingTopologySpreadConstraint",
+		newPodsViolatingTopologySpreadConstraint,
+		framework.WithDefaultSortBy(framework.SortByPriority),
+	)(ctx, client, strategy, nodes, podEvictor, podInformer)
+}
 
+func (d *podsViolatingTopologySpreadConstraint) Balance(ctx context.Context, h *framework.Handle, nodes []*v1.Node) {
 	nodeMap := make(map[string]*v1.Node, len(nodes))
 	for _, node := range nodes {
 		nodeMap[node.Name] = node
@@ -85,7 +102,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 	// if diff > maxSkew, add this pod in the current bucket for eviction
 
 	// First record all of the constraints by namespace
-	namespaces, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	namespaces, err := h.Client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		klog.ErrorS(err, "Couldn't list namespaces")
 		return
@@ -94,11 +111,11 @@ func RemovePodsViolatingTopologySpreadConstraint(
 	podsForEviction := make(map[*v1.Pod]struct{})
 	// 1. for each namespace...
 	for _, namespace := range namespaces.Items {
-		if (len(strategyParams.IncludedNamespaces) > 0 && !strategyParams.IncludedNamespaces.Has(namespace.Name)) ||
-			(len(strategyParams.ExcludedNamespaces) > 0 && strategyParams.ExcludedNamespaces.Has(namespace.Name)) {
+		if (len(d.includedNamespaces) > 0 && !d.includedNamespaces.Has(namespace.Name)) ||
+			(len(d.excludedNamespaces) > 0 && d.excludedNamespaces.Has(namespace.Name)) {
 			continue
 		}
-		namespacePods, err := client.CoreV1().Pods(namespace.Name).List(ctx, metav1.ListOptions{})
+		namespacePods, err := h.Client.CoreV1().Pods(namespace.Name).List(ctx, metav1.ListOptions{})
 		if err != nil {
 			klog.ErrorS(err, "Couldn't list pods in namespace", "namespace", namespace)
 			continue
@@ -109,7 +126,7 @@ func RemovePodsViolatingTopologySpreadConstraint(
 		for _, pod := range namespacePods.Items {
 			for _, constraint := range pod.Spec.TopologySpreadConstraints {
 				// Ignore soft topology constraints if they are not included
-				if constraint.WhenUnsatisfiable == v1.ScheduleAnyway && (strategy.Params == nil || !strategy.Params.IncludeSoftConstraints) {
+				if constraint.WhenUnsatisfiable == v1.ScheduleAnyway && !d.includeSoftConstraints {
 					continue
 				}
 				namespaceTopologySpreadConstraints[constraint] = struct{}{}
@@ -170,15 +187,18 @@ func RemovePodsViolatingTopologySpreadConstraint(
 				klog.V(2).InfoS("Skipping topology constraint because it is already balanced", "constraint", constraint)
 				continue
 			}
-			balanceDomains(podsForEviction, constraint, constraintTopologies, sumPods, evictable.IsEvictable, nodeMap)
+			balanceDomains(podsForEviction, constraint, constraintTopologies, sumPods, h.PreFilter, h.Sort, nodeMap)
 		}
 	}
 
 	for pod := range podsForEviction {
-		if !evictable.IsEvictable(pod) {
+		if !h.PreFilter(pod) {
 			continue
 		}
-		if _, err := podEvictor.EvictPod(ctx, pod, nodeMap[pod.Spec.NodeName], "PodTopologySpread"); err != nil {
+		if _, err := h.PodEvictor.EvictPod(ctx, pod, nodeMap[pod.Spec.NodeName], "PodTopologySpread"); err != nil {
+			if evictions.IsNamespaceLimitReached(err) {
+				continue
+			}
 			klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod))
 			break
 		}
@@ -218,6 +238,7 @@ func topologyIsBalanced(topology map[topologyPair][]*v1.Pod, constraint v1.Topol
 //
 // (Note, we will only move as many pods from a domain as possible without bringing it below the ideal average,
 //  and we will not bring any smaller domain above the average)
+//
 // If the diff is within the skew, we move to the next highest domain.
 // If the higher domain can't give any more without falling below the average, we move to the next lowest "high" domain
 //
@@ -230,10 +251,11 @@ func balanceDomains(
 	constraintTopologies map[topologyPair][]*v1.Pod,
 	sumPods float64,
 	isEvictable func(*v1.Pod) bool,
+	sortPods func([]*v1.Pod),
 	nodeMap map[string]*v1.Node) {
 
 	idealAvg := sumPods / float64(len(constraintTopologies))
-	sortedDomains := sortDomains(constraintTopologies, isEvictable)
+	sortedDomains := sortDomains(constraintTopologies, isEvictable, sortPods)
 	// i is the index for belowOrEqualAvg
 	// j is the index for aboveAvg
 	i := 0
@@ -336,34 +358,37 @@ func validatePodFitsOnOtherNodes(pod *v1.Pod, nodeMap map[string]*v1.Node) error
 }
 
 // sortDomains sorts and splits the list of topology domains based on their size
-// it also sorts the list of pods within the domains based on their node affinity/selector and priority in the following order:
+// it also sorts the list of pods within the domains based on their node affinity/selector and the sorters of the strategy
+// in the following order:
 // 1. non-evictable pods
 // 2. pods with selectors or affinity
-// 3. pods in descending priority
-// 4. all other pods
+// 3. all other pods
+// the pods of each group being in the reverse order of the sorters.
 // We then pop pods off the back of the list for eviction
-func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable func(*v1.Pod) bool) []topology {
+func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable func(*v1.Pod) bool, sortPods func([]*v1.Pod)) []topology {
 	sortedTopologies := make([]topology, 0, len(constraintTopologyPairs))
 	// sort the topologies and return 2 lists: those <= the average and those > the average (> list inverted)
 	for pair, list := range constraintTopologyPairs {
-		// Sort the pods within the domain so that the lowest priority pods are considered first for eviction,
-		// followed by the highest priority,
-		// followed by the lowest priority pods with affinity or nodeSelector,
-		// followed by the highest priority pods with affinity or nodeSelector
-		sort.Slice(list, func(i, j int) bool {
-			// any non-evictable pods should be considered last (ie, first in the list)
-			if !isEvictable(list[i]) || !isEvictable(list[j]) {
-				// false - i is the only non-evictable, so return true to put it first
-				// true - j is non-evictable, so return false to put j before i
-				// if true and both and non-evictable, order doesn't matter
-				return !(isEvictable(list[i]) && !isEvictable(list[j]))
-			}
-
-			// if both pods have selectors/affinity, compare them by their priority
-			if hasSelectorOrAffinity(*list[i]) == hasSelectorOrAffinity(*list[j]) {
-				comparePodsByPriority(list[i], list[j])
+		// Sort the pods within the domain so that the pods the sorters evict first are considered first for eviction,
+		// followed by the pods with affinity or nodeSelector in the same order
+		sortPods(list)
+		for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+			list[i], list[j] = list[j], list[i]
+		}
+		// any non-evictable pods should be considered last (ie, first in the list)
+		ranks := make(map[*v1.Pod]int, len(list))
+		for _, pod := range list {
+			switch {
+			case !isEvictable(pod):
+				ranks[pod] = 0
+			case hasSelectorOrAffinity(*pod):
+				ranks[pod] = 1
+			default:
+				ranks[pod] = 2
 			}
-			return hasSelectorOrAffinity(*list[i]) && !hasSelectorOrAffinity(*list[j])
+		}
+		sort.SliceStable(list, func(i, j int) bool {
+			return ranks[list[i]] < ranks[list[j]]
 		})
 		sortedTopologies = append(sortedTopologies, topology{pair: pair, pods: list})
 	}
@@ -379,22 +404,3 @@ func sortDomains(constraintTopologyPairs map[topologyPair][]*v1.Pod, isEvictable
 func hasSelectorOrAffinity(pod v1.Pod) bool {
 	return pod.Spec.NodeSelector != nil || (pod.Spec.Affinity != nil && pod.Spec.Affinity.NodeAffinity != nil)
 }
-
-// comparePodsByPriority is a helper to the sort function to compare 2 pods based on their priority values
-// It will sort the pods in DESCENDING order of priority, since in our logic we evict pods from the back
-// of the list first.
-func comparePodsByPriority(iPod, jPod *v1.Pod) bool {
-	if iPod.Spec.Priority != nil && jPod.Spec.Priority != nil {
-		// a LOWER priority value should be evicted FIRST
-		return *iPod.Spec.Priority > *jPod.Spec.Priority
-	} else if iPod.Spec.Priority != nil && jPod.Spec.Priority == nil {
-		// i should come before j
-		return true
-	} else if iPod.Spec.Priority == nil && jPod.Spec.Priority != nil {
-		// j should come before i
-		return false
-	} else {
-		// it doesn't matter. just return true
-		return true
-	}
-}
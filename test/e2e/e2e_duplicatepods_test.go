@@ -144,6 +144,8 @@ func TestRemoveDuplicates(t *testing.T) {
 				evictionPolicyGroupVersion,
 				false,
 				0,
+				0,
+				0,
 				nodes,
 				true,
 				false,
@@ -162,6 +164,7 @@ func TestRemoveDuplicates(t *testing.T) {
 				},
 				workerNodes,
 				podEvictor,
+				nil,
 			)
 
 			waitForTerminatingPodsToDisappear(ctx, t, clientSet, testNamespace.Name)
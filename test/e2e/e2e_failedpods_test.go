@@ -94,6 +94,7 @@ func TestFailedPods(t *testing.T) {
 				},
 				nodes,
 				podEvictor,
+				nil,
 			)
 			t.Logf("Finished RemoveFailedPods strategy for %s", name)
 
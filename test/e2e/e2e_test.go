@@ -167,11 +167,14 @@ func runPodLifetimeStrategy(
 			evictionPolicyGroupVersion,
 			false,
 			0,
+			0,
+			0,
 			nodes,
 			false,
 			evictCritical,
 			false,
 		),
+		nil,
 	)
 }
 
@@ -317,6 +320,7 @@ func TestLowNodeUtilization(t *testing.T) {
 		},
 		workerNodes,
 		podEvictor,
+		nil,
 	)
 
 	waitForTerminatingPodsToDisappear(ctx, t, clientSet, rc.Namespace)
@@ -1291,6 +1295,8 @@ func initPodEvictorOrFail(t *testing.T, clientSet clientset.Interface, nodes []*
 		evictionPolicyGroupVersion,
 		false,
 		0,
+		0,
+		0,
 		nodes,
 		true,
 		false,
@@ -134,6 +134,8 @@ func TestTooManyRestarts(t *testing.T) {
 				evictionPolicyGroupVersion,
 				false,
 				0,
+				0,
+				0,
 				nodes,
 				true,
 				false,
@@ -155,6 +157,7 @@ func TestTooManyRestarts(t *testing.T) {
 				},
 				workerNodes,
 				podEvictor,
+				nil,
 			)
 
 			waitForTerminatingPodsToDisappear(ctx, t, clientSet, testNamespace.Name)
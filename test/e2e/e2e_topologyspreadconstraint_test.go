@@ -92,6 +92,7 @@ func TestTopologySpreadConstraint(t *testing.T) {
 				},
 				nodes,
 				podEvictor,
+				nil,
 			)
 			t.Logf("Finished RemovePodsViolatingTopologySpreadConstraint strategy for %s", name)
 